@@ -0,0 +1,41 @@
+package gapi
+
+import (
+	"github.com/zhiduoke/gapi/proto/kvpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// encodeRequest encodes the request by the handler, with fields bound by
+// the path template.
+func (h *routeHandler) encodeRequest(ctx *Context) ([]byte, error) {
+	req, err := h.ch.HandleRequest(h.call, ctx)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	return h.bindVariables(ctx, req)
+}
+
+// bindVariables appends fields captured by the path template, which
+// override fields of the body.
+func (h *routeHandler) bindVariables(ctx *Context, req []byte) ([]byte, error) {
+	b := h.call.Binding
+	if b == nil {
+		return req, nil
+	}
+	for i, v := range b.Template.Variables {
+		fields := b.Variables[i]
+		value, err := kvpb.EncodeField(fields[len(fields)-1], ctx.params.ByName(v.FieldPath))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "bind %s: %v", v.FieldPath, err)
+		}
+		// wrap the value by parent messages, they are merged with the body
+		for j := len(fields) - 2; j >= 0; j-- {
+			parent := protowire.AppendTag(nil, protowire.Number(fields[j].Tag), protowire.BytesType)
+			value = protowire.AppendBytes(parent, value)
+		}
+		req = append(req, value...)
+	}
+	return req, nil
+}
@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"io"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrBodyTooLarge returns the error of a request body over max bytes, which
+// is written as 413.
+func ErrBodyTooLarge(max int64) error {
+	return &HTTPError{
+		Status: http.StatusRequestEntityTooLarge,
+		Err:    status.Errorf(codes.ResourceExhausted, "request body exceeds %d bytes", max),
+	}
+}
+
+// LimitBody limits the request body to max bytes, reading more fails with
+// ErrBodyTooLarge. A Content-Length over max fails at once.
+func LimitBody(ctx *Context, max int64) error {
+	req := ctx.req
+	if req.ContentLength > max {
+		return ErrBodyTooLarge(max)
+	}
+	req.Body = &limitedBody{
+		ReadCloser: http.MaxBytesReader(ctx.resp, req.Body, max),
+		max:        max,
+	}
+	return nil
+}
+
+// limitedBody reports errors of http.MaxBytesReader as ErrBodyTooLarge once
+// max bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	n   int64
+	max int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	if err != nil && err != io.EOF && b.n >= b.max {
+		err = ErrBodyTooLarge(b.max)
+	}
+	return n, err
+}
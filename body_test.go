@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLimitBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		// -1 for an unknown length
+		length int64
+		err    bool
+	}{
+		{"under the limit", "abcd", 4, false},
+		{"content length over the limit", "abcdefgh", 8, true},
+		{"chunked body over the limit", "abcdefgh", -1, true},
+		{"chunked body under the limit", "abc", -1, false},
+	}
+	for _, c := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(c.body))
+		req.ContentLength = c.length
+		ctx := &Context{req: req, resp: httptest.NewRecorder()}
+		err := LimitBody(ctx, 4)
+		if err == nil {
+			var body []byte
+			body, err = ioutil.ReadAll(req.Body)
+			if err == nil && string(body) != c.body {
+				t.Errorf("%s: body %q", c.name, body)
+			}
+		}
+		if !c.err {
+			if err != nil {
+				t.Errorf("%s: %v", c.name, err)
+			}
+			continue
+		}
+		if HTTPStatusFromError(err) != http.StatusRequestEntityTooLarge || status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("%s: error %v", c.name, err)
+		}
+	}
+}
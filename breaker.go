@@ -0,0 +1,263 @@
+package gapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BreakerPolicy configures the circuit breaker of each upstream server.
+type BreakerPolicy struct {
+	Window           time.Duration // 10s by default
+	MinRequests      int           // 20 by default
+	ErrorRate        float64       // 0.5 by default
+	SlowCall         time.Duration // 0 disables slow calls
+	SlowRate         float64       // 0.5 by default
+	OpenTimeout      time.Duration // 5s by default
+	HalfOpenRequests int           // 1 by default
+}
+
+func (p BreakerPolicy) withDefaults() BreakerPolicy {
+	if p.Window <= 0 {
+		p.Window = 10 * time.Second
+	}
+	// at least 1ns per bucket
+	if p.Window < breakerBuckets {
+		p.Window = breakerBuckets
+	}
+	if p.MinRequests <= 0 {
+		p.MinRequests = 20
+	}
+	if p.ErrorRate <= 0 {
+		p.ErrorRate = 0.5
+	}
+	if p.SlowRate <= 0 {
+		p.SlowRate = 0.5
+	}
+	if p.OpenTimeout <= 0 {
+		p.OpenTimeout = 5 * time.Second
+	}
+	if p.HalfOpenRequests <= 0 {
+		p.HalfOpenRequests = 1
+	}
+	return p
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+var breakerStateNames = [...]string{
+	breakerClosed:   "closed",
+	breakerOpen:     "open",
+	breakerHalfOpen: "half-open",
+}
+
+func (s breakerState) String() string {
+	return breakerStateNames[s]
+}
+
+const breakerBuckets = 10
+
+type breakerBucket struct {
+	index    int64
+	total    int
+	failures int
+	slow     int
+}
+
+type breaker struct {
+	server string
+	policy BreakerPolicy
+	now    func() time.Time
+
+	mu        sync.Mutex
+	state     breakerState
+	openedAt  time.Time
+	probes    int
+	successes int
+	buckets   [breakerBuckets]breakerBucket
+}
+
+func newBreaker(server string, policy BreakerPolicy) *breaker {
+	return &breaker{
+		server: server,
+		policy: policy.withDefaults(),
+		now:    time.Now,
+	}
+}
+
+func (b *breaker) bucketIndex(now time.Time) int64 {
+	return now.UnixNano() / int64(b.policy.Window/breakerBuckets)
+}
+
+func (b *breaker) bucket(now time.Time) *breakerBucket {
+	index := b.bucketIndex(now)
+	bk := &b.buckets[index%breakerBuckets]
+	if bk.index != index {
+		*bk = breakerBucket{index: index}
+	}
+	return bk
+}
+
+func (b *breaker) stats(now time.Time) (total, failures, slow int) {
+	index := b.bucketIndex(now)
+	for i := range b.buckets {
+		bk := &b.buckets[i]
+		if bk.index > index-breakerBuckets {
+			total += bk.total
+			failures += bk.failures
+			slow += bk.slow
+		}
+	}
+	return
+}
+
+// allow returns an error if the call should fail fast, otherwise done must be
+// called.
+func (b *breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == breakerOpen && b.now().Sub(b.openedAt) >= b.policy.OpenTimeout {
+		b.state = breakerHalfOpen
+		b.probes = 0
+		b.successes = 0
+	}
+	switch b.state {
+	case breakerOpen:
+		return &breakerError{status.Errorf(codes.Unavailable, "circuit breaker of %s is open", b.server)}
+	case breakerHalfOpen:
+		if b.probes >= b.policy.HalfOpenRequests {
+			return &breakerError{status.Errorf(codes.Unavailable, "circuit breaker of %s is half-open", b.server)}
+		}
+		b.probes++
+	}
+	return nil
+}
+
+// breakerError is never retried.
+type breakerError struct {
+	error
+}
+
+func (e *breakerError) GRPCStatus() *status.Status {
+	return status.Convert(e.error)
+}
+
+// errors caused by the request itself don't count
+func isFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	}
+	return false
+}
+
+// latency is 0 for streams
+func (b *breaker) done(err error, latency time.Duration) {
+	if b == nil {
+		return
+	}
+	failed := isFailure(err)
+	slow := b.policy.SlowCall > 0 && latency >= b.policy.SlowCall
+	now := b.now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerHalfOpen:
+		if failed || slow {
+			b.open(now)
+			return
+		}
+		b.successes++
+		if b.successes >= b.policy.HalfOpenRequests {
+			b.state = breakerClosed
+			b.buckets = [breakerBuckets]breakerBucket{}
+		}
+	case breakerClosed:
+		bk := b.bucket(now)
+		bk.total++
+		if failed {
+			bk.failures++
+		}
+		if slow {
+			bk.slow++
+		}
+		total, failures, slows := b.stats(now)
+		if total < b.policy.MinRequests {
+			return
+		}
+		if float64(failures) >= b.policy.ErrorRate*float64(total) ||
+			(b.policy.SlowCall > 0 && float64(slows) >= b.policy.SlowRate*float64(total)) {
+			b.open(now)
+		}
+	}
+}
+
+func (b *breaker) open(now time.Time) {
+	b.state = breakerOpen
+	b.openedAt = now
+}
+
+type BreakerStatus struct {
+	Server   string     `json:"server"`
+	State    string     `json:"state"`
+	Requests int        `json:"requests"`
+	Failures int        `json:"failures"`
+	Slow     int        `json:"slow"`
+	OpenedAt *time.Time `json:"opened_at,omitempty"`
+}
+
+func (b *breaker) status() *BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := &BreakerStatus{
+		Server: b.server,
+		State:  b.state.String(),
+	}
+	st.Requests, st.Failures, st.Slow = b.stats(b.now())
+	if b.state != breakerClosed {
+		openedAt := b.openedAt
+		st.OpenedAt = &openedAt
+	}
+	return st
+}
+
+func (s *Server) BreakerStatus() []*BreakerStatus {
+	s.routeLock.Lock()
+	breakers := s.breakers
+	s.routeLock.Unlock()
+	list := make([]*BreakerStatus, 0, len(breakers))
+	for _, b := range breakers {
+		list = append(list, b.status())
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Server < list[j].Server
+	})
+	return list
+}
+
+// BreakerHandler serves BreakerStatus as JSON for an admin port.
+func (s *Server) BreakerHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, err := json.Marshal(s.BreakerStatus())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+}
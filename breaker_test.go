@@ -0,0 +1,187 @@
+package gapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func (c *fakeClock) add(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func newTestBreaker(policy BreakerPolicy) (*breaker, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(1600000000, 0)}
+	b := newBreaker("upstream", policy)
+	b.now = clock.now
+	return b, clock
+}
+
+var errUnavailable = status.Error(codes.Unavailable, "unavailable")
+
+// call runs a call through b, it reports whether the call was allowed.
+func (b *breaker) call(err error, latency time.Duration) bool {
+	if b.allow() != nil {
+		return false
+	}
+	b.done(err, latency)
+	return true
+}
+
+func TestBreakerErrorRate(t *testing.T) {
+	b, _ := newTestBreaker(BreakerPolicy{MinRequests: 4, ErrorRate: 0.5})
+	for i := 0; i < 3; i++ {
+		b.call(errUnavailable, 0)
+	}
+	if b.state != breakerClosed {
+		t.Fatal("opened below MinRequests")
+	}
+
+	b, _ = newTestBreaker(BreakerPolicy{MinRequests: 4, ErrorRate: 0.5})
+	b.call(nil, 0)
+	b.call(nil, 0)
+	b.call(nil, 0)
+	// errors of requests don't count
+	b.call(status.Error(codes.InvalidArgument, "bad"), 0)
+	for i := 0; i < 3; i++ {
+		b.call(errUnavailable, 0)
+	}
+	if b.state != breakerClosed {
+		t.Fatalf("opened at 3 of 7")
+	}
+	b.call(errUnavailable, 0)
+	if b.state != breakerOpen {
+		t.Fatalf("closed at 4 of 8")
+	}
+	err := b.allow()
+	if _, ok := err.(*breakerError); !ok || status.Code(err) != codes.Unavailable {
+		t.Fatalf("open breaker allowed: %v", err)
+	}
+}
+
+func TestBreakerWindow(t *testing.T) {
+	b, clock := newTestBreaker(BreakerPolicy{Window: 10 * time.Second, MinRequests: 4})
+	for i := 0; i < 3; i++ {
+		b.call(errUnavailable, 0)
+	}
+	// the failures leave the window
+	clock.add(10 * time.Second)
+	b.call(errUnavailable, 0)
+	if b.state != breakerClosed {
+		t.Fatal("expired calls counted")
+	}
+	if st := b.status(); st.Requests != 1 || st.Failures != 1 {
+		t.Fatalf("status %+v", st)
+	}
+}
+
+func TestBreakerSlowRate(t *testing.T) {
+	b, _ := newTestBreaker(BreakerPolicy{MinRequests: 4, SlowCall: 100 * time.Millisecond, SlowRate: 0.5})
+	b.call(nil, 10*time.Millisecond)
+	b.call(nil, 200*time.Millisecond)
+	b.call(nil, 10*time.Millisecond)
+	b.call(nil, 10*time.Millisecond)
+	if b.state != breakerClosed {
+		t.Fatal("opened at 1 slow call of 4")
+	}
+	b.call(nil, 100*time.Millisecond)
+	b.call(nil, 150*time.Millisecond)
+	if b.state != breakerOpen {
+		t.Fatal("closed at 3 slow calls of 6")
+	}
+
+	// latency isn't checked without SlowCall
+	b, _ = newTestBreaker(BreakerPolicy{MinRequests: 1})
+	b.call(nil, time.Hour)
+	if b.state != breakerClosed {
+		t.Fatal("opened without SlowCall")
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	b, clock := newTestBreaker(BreakerPolicy{MinRequests: 1, OpenTimeout: 5 * time.Second, HalfOpenRequests: 2})
+	b.call(errUnavailable, 0)
+	if b.state != breakerOpen {
+		t.Fatal("not opened")
+	}
+	clock.add(4 * time.Second)
+	if b.allow() == nil {
+		t.Fatal("allowed before OpenTimeout")
+	}
+	clock.add(time.Second)
+	// probes are limited
+	if b.allow() != nil || b.allow() != nil {
+		t.Fatal("probes rejected")
+	}
+	if b.state != breakerHalfOpen {
+		t.Fatalf("state %v", b.state)
+	}
+	if err := b.allow(); err == nil {
+		t.Fatal("allowed more than HalfOpenRequests")
+	}
+	b.done(nil, 0)
+	if b.state != breakerHalfOpen {
+		t.Fatal("closed before all probes succeeded")
+	}
+	b.done(nil, 0)
+	if b.state != breakerClosed {
+		t.Fatal("not closed after probes succeeded")
+	}
+	if st := b.status(); st.Requests != 0 {
+		t.Fatalf("statistics kept after closing: %+v", st)
+	}
+
+	// a failed probe opens it again
+	b.call(errUnavailable, 0)
+	clock.add(5 * time.Second)
+	if !b.call(errUnavailable, 0) {
+		t.Fatal("probe rejected")
+	}
+	if b.state != breakerOpen || !b.openedAt.Equal(clock.now()) {
+		t.Fatalf("state %v opened at %v", b.state, b.openedAt)
+	}
+}
+
+func TestBreakerHandler(t *testing.T) {
+	s := NewServer()
+	open, clock := newTestBreaker(BreakerPolicy{MinRequests: 1})
+	open.server = "b"
+	open.call(errUnavailable, 0)
+	closed, _ := newTestBreaker(BreakerPolicy{})
+	closed.server = "a"
+	closed.call(nil, 0)
+	s.breakers = map[string]*breaker{"b": open, "a": closed}
+
+	w := httptest.NewRecorder()
+	s.BreakerHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/breakers", nil))
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("content type %s", ct)
+	}
+	var list []*BreakerStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("%d breakers", len(list))
+	}
+	a, b := list[0], list[1]
+	if a.Server != "a" || a.State != "closed" || a.Requests != 1 || a.Failures != 0 || a.OpenedAt != nil {
+		t.Fatalf("a: %+v", a)
+	}
+	if b.Server != "b" || b.State != "open" || b.Requests != 1 || b.Failures != 1 || b.OpenedAt == nil || !b.OpenedAt.Equal(clock.now()) {
+		t.Fatalf("b: %+v", b)
+	}
+}
@@ -0,0 +1,80 @@
+package gapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/zhiduoke/gapi/metadata"
+	gapirouter "github.com/zhiduoke/gapi/router"
+)
+
+// RouteConflictError lists routes which can't be registered together.
+type RouteConflictError struct {
+	Conflicts []*RouteConflict
+}
+
+type RouteConflict struct {
+	Route *RouteChange
+	// With is empty if the path itself is invalid.
+	With   []*RouteChange
+	Reason string
+}
+
+func (c *RouteConflict) String() string {
+	s := c.Route.String()
+	if len(c.With) > 0 {
+		with := make([]string, len(c.With))
+		for i, w := range c.With {
+			with[i] = w.String()
+		}
+		s += " conflicts with " + strings.Join(with, ", ")
+	}
+	return s + ": " + c.Reason
+}
+
+func (e *RouteConflictError) Error() string {
+	lines := make([]string, 0, len(e.Conflicts)+1)
+	lines = append(lines, fmt.Sprintf("%d conflicting routes", len(e.Conflicts)))
+	for _, c := range e.Conflicts {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n\t")
+}
+
+// checkRoutes reports all conflicts at once with a scratch router.
+func (s *Server) checkRoutes(routes []*metadata.Route) error {
+	router := s.newRouter()
+	nop := func(http.ResponseWriter, *http.Request, httprouter.Params) {}
+	var (
+		conflicts  []*RouteConflict
+		registered []*metadata.Route
+	)
+	for _, route := range routes {
+		err := router.Handle(route, nop)
+		if err == nil {
+			registered = append(registered, route)
+			continue
+		}
+		// the tree may be left half-inserted by a panic of httprouter
+		router = s.newRouter()
+		for _, r := range registered {
+			router.Handle(r, nop)
+		}
+		c := &RouteConflict{
+			Route:  newRouteChange(route),
+			Reason: err.Error(),
+		}
+		if ce, ok := err.(*gapirouter.ConflictError); ok {
+			for _, r := range ce.With {
+				c.With = append(c.With, newRouteChange(r))
+			}
+		}
+		conflicts = append(conflicts, c)
+	}
+	if len(conflicts) > 0 {
+		return &RouteConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package gapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+func TestCheckRoutes(t *testing.T) {
+	route := func(method, path string) *metadata.Route {
+		return &metadata.Route{
+			Method: method,
+			Path:   path,
+			Call:   &metadata.Call{Name: "/test.Test/Call"},
+		}
+	}
+	tests := []struct {
+		name   string
+		routes []*metadata.Route
+		// conflicting routes and the routes they conflict with
+		conflicts map[string][]string
+	}{
+		{
+			name: "no conflict",
+			routes: []*metadata.Route{
+				route(http.MethodGet, "/user/:id"),
+				route(http.MethodPost, "/user/:id"),
+				route(http.MethodGet, "/users/list"),
+				route(http.MethodGet, "/user/:id/books"),
+			},
+		},
+		{
+			name: "param and static",
+			routes: []*metadata.Route{
+				route(http.MethodGet, "/user/:id"),
+				route(http.MethodGet, "/user/list"),
+			},
+			conflicts: map[string][]string{"GET /user/list": {"GET /user/:id"}},
+		},
+		{
+			name: "duplicated",
+			routes: []*metadata.Route{
+				route(http.MethodGet, "/user/list"),
+				route(http.MethodGet, "/user/list"),
+			},
+			conflicts: map[string][]string{"GET /user/list": {"GET /user/list"}},
+		},
+		{
+			name: "after a conflict",
+			routes: []*metadata.Route{
+				route(http.MethodGet, "/user/:id"),
+				route(http.MethodGet, "/user/list"),
+				route(http.MethodGet, "/user/:id/books"),
+				route(http.MethodGet, "/book/:id"),
+				route(http.MethodGet, "/book/:name"),
+			},
+			conflicts: map[string][]string{
+				"GET /user/list":  {"GET /user/:id"},
+				"GET /book/:name": {"GET /book/:id"},
+			},
+		},
+	}
+	for _, c := range tests {
+		err := NewServer().checkRoutes(c.routes)
+		if len(c.conflicts) == 0 {
+			if err != nil {
+				t.Errorf("%s: %v", c.name, err)
+			}
+			continue
+		}
+		ce, ok := err.(*RouteConflictError)
+		if !ok {
+			t.Errorf("%s: error %v", c.name, err)
+			continue
+		}
+		if len(ce.Conflicts) != len(c.conflicts) {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		for _, conflict := range ce.Conflicts {
+			key := conflict.Route.Method + " " + conflict.Route.Path
+			want, ok := c.conflicts[key]
+			if !ok || len(conflict.With) != len(want) {
+				t.Errorf("%s: %s", c.name, conflict)
+				continue
+			}
+			for i, w := range conflict.With {
+				if w.Method+" "+w.Path != want[i] {
+					t.Errorf("%s: %s", c.name, conflict)
+				}
+			}
+		}
+	}
+}
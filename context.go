@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/zhiduoke/gapi/metadata"
+	grpcmd "google.golang.org/grpc/metadata"
 )
 
 type Context struct {
@@ -13,6 +15,13 @@ type Context struct {
 	next   int
 	values map[string]string
 	params httprouter.Params
+	route  *metadata.Route
+	call   *metadata.Call
+	// resolver of the loaded descriptors
+	resolver metadata.MessageResolver
+
+	upstreamHeader  grpcmd.MD
+	upstreamTrailer grpcmd.MD
 }
 
 func (ctx *Context) Set(name string, value string) {
@@ -27,6 +36,17 @@ func (ctx *Context) Get(name string) (string, bool) {
 	return v, ok
 }
 
+// Route returns the matched route, additional bindings of a call are routes
+// of their own.
+func (ctx *Context) Route() *metadata.Route {
+	return ctx.route
+}
+
+// Call returns the call of the route.
+func (ctx *Context) Call() *metadata.Call {
+	return ctx.call
+}
+
 func (ctx *Context) Request() *http.Request {
 	return ctx.req
 }
@@ -59,4 +79,20 @@ func (ctx *Context) reset(w http.ResponseWriter, req *http.Request, params httpr
 	ctx.next = 0
 	ctx.values = nil
 	ctx.params = params
+	ctx.route = nil
+	ctx.call = nil
+	ctx.resolver = nil
+	ctx.upstreamHeader = nil
+	ctx.upstreamTrailer = nil
+}
+
+// UpstreamHeader returns the header metadata of the upstream response.
+func (ctx *Context) UpstreamHeader() grpcmd.MD {
+	return ctx.upstreamHeader
+}
+
+// UpstreamTrailer returns the trailer metadata of the upstream response, it's
+// available once the call finished.
+func (ctx *Context) UpstreamTrailer() grpcmd.MD {
+	return ctx.upstreamTrailer
 }
@@ -0,0 +1,328 @@
+package gapi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+// RouteChange is a route in RouteReport.
+type RouteChange struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Call   string `json:"call"`
+	// Changes name what changed, e.g. server or middlewares.
+	Changes []string `json:"changes,omitempty"`
+}
+
+func (c *RouteChange) String() string {
+	s := c.Method + " " + c.Path + " (" + c.Call + ")"
+	if len(c.Changes) > 0 {
+		s += ": " + strings.Join(c.Changes, ", ")
+	}
+	return s
+}
+
+// RouteReport is the difference between the installed routes and the new ones.
+type RouteReport struct {
+	DryRun    bool           `json:"dry_run"`
+	Added     []*RouteChange `json:"added,omitempty"`
+	Removed   []*RouteChange `json:"removed,omitempty"`
+	Changed   []*RouteChange `json:"changed,omitempty"`
+	Unchanged int            `json:"unchanged"`
+	// servers with a balancer are "server#balancer"
+	AddedServers    []string `json:"added_servers,omitempty"`
+	RemovedServers  []string `json:"removed_servers,omitempty"`
+	ChangedMessages []string `json:"changed_messages,omitempty"`
+
+	// keys of unchanged routes
+	unchanged map[string]bool
+}
+
+func (r *RouteReport) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0 &&
+		len(r.AddedServers) == 0 && len(r.RemovedServers) == 0 && len(r.ChangedMessages) == 0
+}
+
+func (r *RouteReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d added, %d removed, %d changed, %d unchanged",
+		len(r.Added), len(r.Removed), len(r.Changed), r.Unchanged)
+	for _, c := range r.Added {
+		b.WriteString("\n+ " + c.String())
+	}
+	for _, c := range r.Removed {
+		b.WriteString("\n- " + c.String())
+	}
+	for _, c := range r.Changed {
+		b.WriteString("\n~ " + c.String())
+	}
+	for _, s := range r.AddedServers {
+		b.WriteString("\n+ server " + s)
+	}
+	for _, s := range r.RemovedServers {
+		b.WriteString("\n- server " + s)
+	}
+	for _, m := range r.ChangedMessages {
+		b.WriteString("\n~ message " + m)
+	}
+	return b.String()
+}
+
+func routeKey(route *metadata.Route) string {
+	return route.Method + " " + route.Path
+}
+
+func clientKey(call *metadata.Call) string {
+	if call.Balancer != "" {
+		return call.Server + "#" + call.Balancer
+	}
+	return call.Server
+}
+
+func newRouteChange(route *metadata.Route) *RouteChange {
+	return &RouteChange{
+		Method: route.Method,
+		Path:   route.Path,
+		Call:   route.Call.Name,
+	}
+}
+
+// old is nil before the first update
+func diffRoutes(old, md *metadata.Metadata) *RouteReport {
+	report := &RouteReport{unchanged: map[string]bool{}}
+	var oldRoutes []*metadata.Route
+	if old != nil {
+		oldRoutes = old.Routes
+	}
+	oldSchema, newSchema := schemaOf(oldRoutes), schemaOf(md.Routes)
+	changedMessages := map[string]bool{}
+	for name, sig := range newSchema.sigs {
+		if oldSig, ok := oldSchema.sigs[name]; ok && oldSig != sig {
+			changedMessages[name] = true
+			report.ChangedMessages = append(report.ChangedMessages, name)
+		}
+	}
+	sort.Strings(report.ChangedMessages)
+
+	routes := make(map[string]*metadata.Route, len(oldRoutes))
+	oldServers := map[string]bool{}
+	for _, route := range oldRoutes {
+		routes[routeKey(route)] = route
+		oldServers[clientKey(route.Call)] = true
+	}
+	seen := make(map[string]bool, len(md.Routes))
+	newServers := map[string]bool{}
+	for _, route := range md.Routes {
+		key := routeKey(route)
+		seen[key] = true
+		newServers[clientKey(route.Call)] = true
+		prev := routes[key]
+		if prev == nil {
+			report.Added = append(report.Added, newRouteChange(route))
+			continue
+		}
+		changes := routeChanges(prev, route, newSchema, changedMessages)
+		if len(changes) == 0 {
+			report.Unchanged++
+			report.unchanged[key] = true
+			continue
+		}
+		c := newRouteChange(route)
+		c.Changes = changes
+		report.Changed = append(report.Changed, c)
+	}
+	for _, route := range oldRoutes {
+		if !seen[routeKey(route)] {
+			report.Removed = append(report.Removed, newRouteChange(route))
+		}
+	}
+	for server := range newServers {
+		if !oldServers[server] {
+			report.AddedServers = append(report.AddedServers, server)
+		}
+	}
+	for server := range oldServers {
+		if !newServers[server] {
+			report.RemovedServers = append(report.RemovedServers, server)
+		}
+	}
+	sort.Strings(report.AddedServers)
+	sort.Strings(report.RemovedServers)
+	return report
+}
+
+func routeChanges(old, route *metadata.Route, schema *schema, changedMessages map[string]bool) []string {
+	var changes []string
+	a, b := old.Call, route.Call
+	if a.Name != b.Name {
+		changes = append(changes, "call")
+	}
+	if a.Server != b.Server || a.Balancer != b.Balancer {
+		changes = append(changes, "server")
+	}
+	if a.Handler != b.Handler {
+		changes = append(changes, "handler")
+	}
+	if a.Timeout != b.Timeout {
+		changes = append(changes, "timeout")
+	}
+	if a.ClientStreaming != b.ClientStreaming || a.ServerStreaming != b.ServerStreaming {
+		changes = append(changes, "streaming")
+	}
+	if a.Naming != b.Naming {
+		changes = append(changes, "naming")
+	}
+	if !equalUpload(a.Upload, b.Upload) {
+		changes = append(changes, "upload")
+	}
+	if !equalBinding(a.Binding, b.Binding) {
+		changes = append(changes, "binding")
+	}
+	if !reflect.DeepEqual(a.Retry, b.Retry) {
+		changes = append(changes, "retry")
+	}
+	if a.Limits != b.Limits {
+		changes = append(changes, "limits")
+	}
+	if !equalMiddlewares(old.Options.Middlewares, route.Options.Middlewares) {
+		changes = append(changes, "middlewares")
+	}
+	if !equalStrings(old.Options.ForwardHeaders, route.Options.ForwardHeaders) {
+		changes = append(changes, "forward_headers")
+	}
+	if !equalStrings(old.Options.ForwardContext, route.Options.ForwardContext) {
+		changes = append(changes, "forward_context")
+	}
+	if a.In.Name != b.In.Name || schema.changed(b.In, changedMessages) {
+		changes = append(changes, "request")
+	}
+	if a.Out.Name != b.Out.Name || schema.changed(b.Out, changedMessages) {
+		changes = append(changes, "response")
+	}
+	return changes
+}
+
+func equalUpload(a, b *metadata.Upload) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ChunkField.Tag == b.ChunkField.Tag && a.ChunkSize == b.ChunkSize && a.FormFile == b.FormFile
+}
+
+func equalBinding(a, b *metadata.Binding) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	// fields are compared by the schema of messages
+	return a.Body == b.Body && fieldName(a.ResponseBody) == fieldName(b.ResponseBody) &&
+		reflect.DeepEqual(a.Template, b.Template)
+}
+
+func fieldName(f *metadata.Field) string {
+	if f == nil {
+		return ""
+	}
+	return f.Name
+}
+
+func equalMiddlewares(a, b []*metadata.Middleware) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].String() != b[i].String() {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// schema keeps signatures of messages used by routes.
+type schema struct {
+	sigs map[string]string
+	deps map[string][]*metadata.Message
+}
+
+func schemaOf(routes []*metadata.Route) *schema {
+	s := &schema{
+		sigs: map[string]string{},
+		deps: map[string][]*metadata.Message{},
+	}
+	for _, route := range routes {
+		s.add(route.Call.In)
+		s.add(route.Call.Out)
+	}
+	return s
+}
+
+func (s *schema) add(msg *metadata.Message) {
+	if _, ok := s.sigs[msg.Name]; ok {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %v %v %v;", msg.WellKnown, msg.Options.Flat, msg.Options.EnumsAsString, msg.Options.Int64sAsString)
+	var deps []*metadata.Message
+	for _, f := range msg.Fields {
+		fmt.Fprintf(&b, "%d %s %s %s %d %v %v %+v", f.Tag, f.Name, f.JSONName, f.CamelName, f.Kind, f.Repeated, f.Optional, f.Options)
+		if f.Oneof != nil {
+			b.WriteString(" oneof " + f.Oneof.Name)
+		}
+		if f.Enum != nil {
+			b.WriteString(" " + f.Enum.Name)
+			for _, v := range f.Enum.Values {
+				fmt.Fprintf(&b, " %s=%d", v.Name, v.Number)
+			}
+		}
+		if f.Message != nil {
+			b.WriteString(" " + f.Message.Name)
+			deps = append(deps, f.Message)
+		}
+		b.WriteByte(';')
+	}
+	s.sigs[msg.Name] = b.String()
+	s.deps[msg.Name] = deps
+	for _, dep := range deps {
+		s.add(dep)
+	}
+}
+
+// changed reports whether msg or messages used by it changed.
+func (s *schema) changed(msg *metadata.Message, changedMessages map[string]bool) bool {
+	if len(changedMessages) == 0 {
+		return false
+	}
+	visited := map[string]bool{}
+	var walk func(msg *metadata.Message) bool
+	walk = func(msg *metadata.Message) bool {
+		if visited[msg.Name] {
+			return false
+		}
+		visited[msg.Name] = true
+		if changedMessages[msg.Name] {
+			return true
+		}
+		for _, dep := range s.deps[msg.Name] {
+			if walk(dep) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(msg)
+}
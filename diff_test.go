@@ -0,0 +1,94 @@
+package gapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+func TestDiffRoutes(t *testing.T) {
+	req := &metadata.Message{Name: "test.Req", Fields: []*metadata.Field{{Tag: 1, Name: "a", Kind: metadata.StringKind}}}
+	res := &metadata.Message{Name: "test.Res", Fields: []*metadata.Field{{Tag: 1, Name: "b", Kind: metadata.StringKind}}}
+	call := func(server string) *metadata.Call {
+		return &metadata.Call{Server: server, Handler: "httpjson", Name: "/test.Test/Call", In: req, Out: res}
+	}
+	route := func(method, path string, call *metadata.Call) *metadata.Route {
+		return &metadata.Route{Method: method, Path: path, Call: call}
+	}
+	binding := func(path, body, responseBody string) *metadata.Binding {
+		template, err := metadata.ParseTemplate(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := &metadata.Binding{Template: template, Body: body}
+		if responseBody != "" {
+			b.ResponseBody = res.Fields[0]
+		}
+		return b
+	}
+	withBinding := func(c *metadata.Call, b *metadata.Binding) *metadata.Call {
+		c.Binding = b
+		return c
+	}
+	old := &metadata.Metadata{Routes: []*metadata.Route{
+		route("GET", "/a", call("a:80")),
+		route("GET", "/removed", call("a:80")),
+		route("GET", "/server", call("a:80")),
+		route("POST", "/v1/{a}", withBinding(call("a:80"), binding("/v1/{a}", "*", ""))),
+		route("GET", "/v1/{a}", withBinding(call("a:80"), binding("/v1/{a}", "", ""))),
+		route("GET", "/gone", call("gone:80")),
+	}}
+	md := &metadata.Metadata{Routes: []*metadata.Route{
+		route("GET", "/a", call("a:80")),
+		route("GET", "/added", call("a:80")),
+		route("GET", "/server", call("b:80")),
+		route("POST", "/v1/{a}", withBinding(call("a:80"), binding("/v1/{a}", "", ""))),
+		route("GET", "/v1/{a}", withBinding(call("a:80"), binding("/v1/{a}", "", "b"))),
+	}}
+	report := diffRoutes(old, md)
+	paths := func(changes []*RouteChange) []string {
+		var list []string
+		for _, c := range changes {
+			list = append(list, c.Method+" "+c.Path)
+		}
+		return list
+	}
+	if got := paths(report.Added); !reflect.DeepEqual(got, []string{"GET /added"}) {
+		t.Errorf("added %v", got)
+	}
+	if got := paths(report.Removed); !reflect.DeepEqual(got, []string{"GET /removed", "GET /gone"}) {
+		t.Errorf("removed %v", got)
+	}
+	if got := paths(report.Changed); !reflect.DeepEqual(got, []string{"GET /server", "POST /v1/{a}", "GET /v1/{a}"}) {
+		t.Fatalf("changed %v", got)
+	}
+	for i, want := range [][]string{{"server"}, {"binding"}, {"binding"}} {
+		if got := report.Changed[i].Changes; !reflect.DeepEqual(got, want) {
+			t.Errorf("changes of %s: %v", report.Changed[i], got)
+		}
+	}
+	if report.Unchanged != 1 || !report.unchanged["GET /a"] {
+		t.Errorf("unchanged %d", report.Unchanged)
+	}
+	if !reflect.DeepEqual(report.AddedServers, []string{"b:80"}) || !reflect.DeepEqual(report.RemovedServers, []string{"gone:80"}) {
+		t.Errorf("servers +%v -%v", report.AddedServers, report.RemovedServers)
+	}
+
+	// fields of messages
+	res2 := *res
+	res2.Fields = []*metadata.Field{{Tag: 2, Name: "b", Kind: metadata.StringKind}}
+	c := call("a:80")
+	c.Out = &res2
+	report = diffRoutes(old, &metadata.Metadata{Routes: []*metadata.Route{route("GET", "/a", c)}})
+	if !reflect.DeepEqual(report.ChangedMessages, []string{"test.Res"}) {
+		t.Errorf("changed messages %v", report.ChangedMessages)
+	}
+	if len(report.Changed) != 1 || !reflect.DeepEqual(report.Changed[0].Changes, []string{"response"}) {
+		t.Errorf("changed %v", report.Changed)
+	}
+
+	if report := diffRoutes(md, md); !report.Empty() {
+		t.Errorf("%s", report)
+	}
+}
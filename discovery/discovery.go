@@ -0,0 +1,56 @@
+// Package discovery adds static, srv and file resolvers and the least_request
+// balancer, an endpoints file lists an address per line.
+package discovery
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	PickFirst    = "pick_first"
+	RoundRobin   = roundrobin.Name
+	LeastRequest = "least_request"
+)
+
+var (
+	SRVRefresh  = 30 * time.Second
+	FileRefresh = time.Second
+)
+
+func init() {
+	resolver.Register(staticBuilder{})
+	resolver.Register(srvBuilder{})
+	resolver.Register(fileBuilder{})
+	balancer.Register(newLeastRequestBuilder())
+}
+
+func CheckBalancer(name string) error {
+	if name != "" && balancer.Get(name) == nil {
+		return fmt.Errorf("invalid balancer: %s", name)
+	}
+	return nil
+}
+
+// Dial dials server insecurely unless opts override it, "" is pick_first.
+func Dial(server string, balancerName string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if err := CheckBalancer(balancerName); err != nil {
+		return nil, err
+	}
+	dopts := []grpc.DialOption{grpc.WithInsecure()}
+	if balancerName != "" {
+		dopts = append(dopts, grpc.WithDefaultServiceConfig(
+			fmt.Sprintf(`{"loadBalancingConfig":[{%q:{}}]}`, balancerName)))
+	}
+	return grpc.Dial(server, append(dopts, opts...)...)
+}
+
+// file:///etc/x is /etc/x and file://./x is ./x
+func targetPath(target resolver.Target) string {
+	return target.Authority + "/" + target.Endpoint
+}
@@ -0,0 +1,25 @@
+package discovery
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/resolver"
+)
+
+func TestParseEndpoints(t *testing.T) {
+	addrs := parseEndpoints([]byte("# users\n10.0.0.1:9090\n\n  10.0.0.2:9090  \r\n"))
+	want := []resolver.Address{{Addr: "10.0.0.1:9090"}, {Addr: "10.0.0.2:9090"}}
+	if !reflect.DeepEqual(addrs, want) {
+		t.Fatalf("%v", addrs)
+	}
+}
+
+func TestTargetPath(t *testing.T) {
+	if p := targetPath(resolver.Target{Endpoint: "etc/eps"}); p != "/etc/eps" {
+		t.Fatal(p)
+	}
+	if p := targetPath(resolver.Target{Authority: ".", Endpoint: "eps"}); p != "./eps" {
+		t.Fatal(p)
+	}
+}
@@ -0,0 +1,98 @@
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/resolver"
+)
+
+type fileBuilder struct{}
+
+func (fileBuilder) Scheme() string {
+	return "file"
+}
+
+func (fileBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	r := &fileResolver{
+		path: targetPath(target),
+		cc:   cc,
+		done: make(chan struct{}),
+	}
+	// fail fast if the file is missing
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	go r.watch()
+	return r, nil
+}
+
+type fileResolver struct {
+	path    string
+	cc      resolver.ClientConn
+	done    chan struct{}
+	modTime time.Time
+	size    int64
+}
+
+func (r *fileResolver) watch() {
+	ticker := time.NewTicker(FileRefresh)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			if err := r.load(); err != nil {
+				// keep the last endpoints
+				logrus.Warnf("load endpoints: %v", err)
+				r.cc.ReportError(err)
+			}
+		}
+	}
+}
+
+func (r *fileResolver) load() error {
+	fi, err := os.Stat(r.path)
+	if err != nil {
+		return err
+	}
+	if fi.ModTime().Equal(r.modTime) && fi.Size() == r.size {
+		return nil
+	}
+	data, err := ioutil.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+	addrs := parseEndpoints(data)
+	if len(addrs) == 0 {
+		return fmt.Errorf("no address in %s", r.path)
+	}
+	r.modTime, r.size = fi.ModTime(), fi.Size()
+	r.cc.UpdateState(resolver.State{Addresses: addrs})
+	return nil
+}
+
+func parseEndpoints(data []byte) []resolver.Address {
+	var addrs []resolver.Address
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		addrs = append(addrs, resolver.Address{Addr: string(line)})
+	}
+	return addrs
+}
+
+func (r *fileResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (r *fileResolver) Close() {
+	close(r.done)
+}
@@ -0,0 +1,92 @@
+package discovery
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+func newLeastRequestBuilder() balancer.Builder {
+	return leastRequestBuilder{}
+}
+
+// leastRequestBuilder builds a balancer with a picker builder of its own, as
+// the counters of requests in flight belong to the SubConns of a ClientConn.
+type leastRequestBuilder struct{}
+
+func (leastRequestBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	pb := &leastRequestPickerBuilder{subConns: map[balancer.SubConn]*leastRequestSubConn{}}
+	return base.NewBalancerBuilder(LeastRequest, pb, base.Config{HealthCheck: true}).Build(cc, opts)
+}
+
+func (leastRequestBuilder) Name() string {
+	return LeastRequest
+}
+
+// leastRequestPickerBuilder keeps the counters of SubConns across pickers,
+// Build is called by the balancer one at a time.
+type leastRequestPickerBuilder struct {
+	subConns map[balancer.SubConn]*leastRequestSubConn
+}
+
+func (b *leastRequestPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	for sc := range b.subConns {
+		if _, ok := info.ReadySCs[sc]; !ok {
+			delete(b.subConns, sc)
+		}
+	}
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	p := &leastRequestPicker{
+		rand: rand.New(rand.NewSource(rand.Int63())),
+	}
+	for sc := range info.ReadySCs {
+		lsc := b.subConns[sc]
+		if lsc == nil {
+			lsc = &leastRequestSubConn{sc: sc}
+			b.subConns[sc] = lsc
+		}
+		p.subConns = append(p.subConns, lsc)
+	}
+	return p
+}
+
+type leastRequestSubConn struct {
+	sc       balancer.SubConn
+	inflight int64
+}
+
+// leastRequestPicker picks the less busy of two random SubConns.
+type leastRequestPicker struct {
+	subConns []*leastRequestSubConn
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func (p *leastRequestPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	sc := p.subConns[0]
+	if n := len(p.subConns); n > 1 {
+		p.mu.Lock()
+		i, j := p.rand.Intn(n), p.rand.Intn(n-1)
+		p.mu.Unlock()
+		if j >= i {
+			j++
+		}
+		sc = p.subConns[i]
+		if other := p.subConns[j]; atomic.LoadInt64(&other.inflight) < atomic.LoadInt64(&sc.inflight) {
+			sc = other
+		}
+	}
+	atomic.AddInt64(&sc.inflight, 1)
+	return balancer.PickResult{
+		SubConn: sc.sc,
+		Done: func(balancer.DoneInfo) {
+			atomic.AddInt64(&sc.inflight, -1)
+		},
+	}, nil
+}
@@ -0,0 +1,117 @@
+package discovery
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+)
+
+type fakeSubConn struct {
+	balancer.SubConn
+	addr string
+}
+
+func newPickerBuilder() *leastRequestPickerBuilder {
+	return &leastRequestPickerBuilder{subConns: map[balancer.SubConn]*leastRequestSubConn{}}
+}
+
+func readySubConns(scs ...balancer.SubConn) base.PickerBuildInfo {
+	info := base.PickerBuildInfo{ReadySCs: map[balancer.SubConn]base.SubConnInfo{}}
+	for _, sc := range scs {
+		info.ReadySCs[sc] = base.SubConnInfo{}
+	}
+	return info
+}
+
+func buildPicker(addrs ...string) balancer.Picker {
+	var scs []balancer.SubConn
+	for _, addr := range addrs {
+		scs = append(scs, &fakeSubConn{addr: addr})
+	}
+	return newPickerBuilder().Build(readySubConns(scs...))
+}
+
+func TestLeastRequestPicker(t *testing.T) {
+	if _, err := buildPicker().Pick(balancer.PickInfo{}); err != balancer.ErrNoSubConnAvailable {
+		t.Fatalf("no subconn: %v", err)
+	}
+
+	// the other one has fewer requests in flight
+	p := buildPicker("a", "b")
+	first, err := p.Pick(balancer.PickInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := p.Pick(balancer.PickInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.SubConn == second.SubConn {
+		t.Fatal("picked the busy subconn")
+	}
+	first.Done(balancer.DoneInfo{})
+	for i := 0; i < 10; i++ {
+		res, err := p.Pick(balancer.PickInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.SubConn != first.SubConn {
+			t.Fatal("requests in flight aren't released")
+		}
+		res.Done(balancer.DoneInfo{})
+	}
+
+	// the busiest one loses every comparison
+	lp := buildPicker("a", "b", "c").(*leastRequestPicker)
+	busy := lp.subConns[0]
+	busy.inflight = 100
+	picked := map[balancer.SubConn]int{}
+	for i := 0; i < 100; i++ {
+		res, err := lp.Pick(balancer.PickInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		picked[res.SubConn]++
+		res.Done(balancer.DoneInfo{})
+	}
+	if picked[busy.sc] != 0 || len(picked) != 2 {
+		t.Fatalf("picked %v", picked)
+	}
+}
+
+func TestLeastRequestRebuild(t *testing.T) {
+	a, b, c := &fakeSubConn{addr: "a"}, &fakeSubConn{addr: "b"}, &fakeSubConn{addr: "c"}
+	pb := newPickerBuilder()
+	p := pb.Build(readySubConns(a, b))
+	res, err := p.Pick(balancer.PickInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	busy := res.SubConn
+
+	// requests in flight are counted by the new picker as well
+	p = pb.Build(readySubConns(a, b, c))
+	if n := pb.subConns[busy].inflight; n != 1 {
+		t.Fatalf("%d requests in flight after a rebuild", n)
+	}
+	for _, lsc := range p.(*leastRequestPicker).subConns {
+		if lsc.sc == busy && lsc.inflight != 1 {
+			t.Fatalf("the picker counts %d requests in flight", lsc.inflight)
+		}
+	}
+	res.Done(balancer.DoneInfo{})
+	if n := pb.subConns[busy].inflight; n != 0 {
+		t.Fatalf("%d requests in flight after done", n)
+	}
+
+	// removed SubConns are dropped
+	pb.Build(readySubConns(c))
+	if len(pb.subConns) != 1 || pb.subConns[c] == nil {
+		t.Fatalf("subconns %v", pb.subConns)
+	}
+	pb.Build(readySubConns())
+	if len(pb.subConns) != 0 {
+		t.Fatalf("subconns %v", pb.subConns)
+	}
+}
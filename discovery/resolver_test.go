@@ -0,0 +1,152 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+)
+
+// fakeClientConn records states and errors reported by resolvers.
+type fakeClientConn struct {
+	resolver.ClientConn
+	states chan resolver.State
+	errs   chan error
+}
+
+func newFakeClientConn() *fakeClientConn {
+	return &fakeClientConn{
+		states: make(chan resolver.State, 10),
+		errs:   make(chan error, 10),
+	}
+}
+
+func (cc *fakeClientConn) UpdateState(state resolver.State) {
+	cc.states <- state
+}
+
+func (cc *fakeClientConn) ReportError(err error) {
+	cc.errs <- err
+}
+
+func (cc *fakeClientConn) addrs(t *testing.T) []string {
+	t.Helper()
+	select {
+	case state := <-cc.states:
+		var addrs []string
+		for _, addr := range state.Addresses {
+			addrs = append(addrs, addr.Addr)
+		}
+		return addrs
+	case <-time.After(time.Second):
+		t.Fatal("no state updated")
+		return nil
+	}
+}
+
+func TestStaticResolver(t *testing.T) {
+	cc := newFakeClientConn()
+	r, err := staticBuilder{}.Build(resolver.Target{Endpoint: "10.0.0.1:9090, 10.0.0.2:9090,"}, cc, resolver.BuildOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if addrs := cc.addrs(t); !reflect.DeepEqual(addrs, []string{"10.0.0.1:9090", "10.0.0.2:9090"}) {
+		t.Fatalf("%v", addrs)
+	}
+	if _, err := (staticBuilder{}).Build(resolver.Target{Endpoint: " , "}, cc, resolver.BuildOptions{}); err == nil {
+		t.Fatal("empty target passed")
+	}
+}
+
+func TestSRVResolver(t *testing.T) {
+	records := []*net.SRV{{Target: "a.example.com.", Port: 9090}, {Target: "b.example.com", Port: 9091}}
+	var lookupErr error
+	lookups := make(chan string, 10)
+	defer func(fn func(context.Context, string, string, string) (string, []*net.SRV, error)) {
+		lookupSRV = fn
+	}(lookupSRV)
+	lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		err := lookupErr
+		lookups <- name
+		return "", records, err
+	}
+	cc := newFakeClientConn()
+	r, err := srvBuilder{}.Build(resolver.Target{Endpoint: "_grpc._tcp.users"}, cc, resolver.BuildOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if name := <-lookups; name != "_grpc._tcp.users" {
+		t.Fatal(name)
+	}
+	if addrs := cc.addrs(t); !reflect.DeepEqual(addrs, []string{"a.example.com:9090", "b.example.com:9091"}) {
+		t.Fatalf("%v", addrs)
+	}
+	// the lookup is synchronized by the lookups channel
+	lookupErr = errors.New("no such host")
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	<-lookups
+	select {
+	case <-cc.errs:
+	case <-time.After(time.Second):
+		t.Fatal("no error reported")
+	}
+}
+
+func TestFileResolver(t *testing.T) {
+	defer func(d time.Duration) {
+		FileRefresh = d
+	}(FileRefresh)
+	FileRefresh = 10 * time.Millisecond
+	dir, err := ioutil.TempDir("", "discovery")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "users.endpoints")
+	target := resolver.Target{Endpoint: path[1:]}
+	cc := newFakeClientConn()
+	if _, err := (fileBuilder{}).Build(target, cc, resolver.BuildOptions{}); err == nil {
+		t.Fatal("missing file passed")
+	}
+	if err := ioutil.WriteFile(path, []byte("10.0.0.1:9090\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := fileBuilder{}.Build(target, cc, resolver.BuildOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if addrs := cc.addrs(t); !reflect.DeepEqual(addrs, []string{"10.0.0.1:9090"}) {
+		t.Fatalf("%v", addrs)
+	}
+	// the size changes even if the modification time doesn't
+	if err := ioutil.WriteFile(path, []byte("10.0.0.1:9090\n10.0.0.2:9090\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if addrs := cc.addrs(t); !reflect.DeepEqual(addrs, []string{"10.0.0.1:9090", "10.0.0.2:9090"}) {
+		t.Fatalf("%v", addrs)
+	}
+	// the last endpoints are kept
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-cc.errs:
+	case <-time.After(time.Second):
+		t.Fatal("no error reported")
+	}
+	select {
+	case state := <-cc.states:
+		t.Fatalf("unexpected state %v", state)
+	default:
+	}
+}
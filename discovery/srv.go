@@ -0,0 +1,87 @@
+package discovery
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/resolver"
+)
+
+// lookupSRV is replaced by tests.
+var lookupSRV = net.DefaultResolver.LookupSRV
+
+type srvBuilder struct{}
+
+func (srvBuilder) Scheme() string {
+	return "srv"
+}
+
+func (srvBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &srvResolver{
+		name:   target.Endpoint,
+		cc:     cc,
+		now:    make(chan struct{}, 1),
+		cancel: cancel,
+	}
+	go r.watch(ctx)
+	return r, nil
+}
+
+// priorities and weights of records are ignored
+type srvResolver struct {
+	name   string
+	cc     resolver.ClientConn
+	now    chan struct{}
+	cancel context.CancelFunc
+}
+
+func (r *srvResolver) watch(ctx context.Context) {
+	ticker := time.NewTicker(SRVRefresh)
+	defer ticker.Stop()
+	for {
+		r.lookup(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.now:
+		}
+	}
+}
+
+func (r *srvResolver) lookup(ctx context.Context) {
+	_, records, err := lookupSRV(ctx, "", "", r.name)
+	if err != nil {
+		if ctx.Err() == nil {
+			logrus.Warnf("lookup srv %s: %v", r.name, err)
+			r.cc.ReportError(err)
+		}
+		return
+	}
+	addrs := make([]resolver.Address, 0, len(records))
+	for _, rec := range records {
+		host := rec.Target
+		if n := len(host); n > 0 && host[n-1] == '.' {
+			host = host[:n-1]
+		}
+		addrs = append(addrs, resolver.Address{
+			Addr: net.JoinHostPort(host, strconv.Itoa(int(rec.Port))),
+		})
+	}
+	r.cc.UpdateState(resolver.State{Addresses: addrs})
+}
+
+func (r *srvResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.now <- struct{}{}:
+	default:
+	}
+}
+
+func (r *srvResolver) Close() {
+	r.cancel()
+}
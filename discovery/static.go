@@ -0,0 +1,36 @@
+package discovery
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/resolver"
+)
+
+type staticBuilder struct{}
+
+func (staticBuilder) Scheme() string {
+	return "static"
+}
+
+func (staticBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	var addrs []resolver.Address
+	for _, addr := range strings.Split(target.Endpoint, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		addrs = append(addrs, resolver.Address{Addr: addr})
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no address in static:///%s", target.Endpoint)
+	}
+	cc.UpdateState(resolver.State{Addresses: addrs})
+	return nopResolver{}, nil
+}
+
+type nopResolver struct{}
+
+func (nopResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (nopResolver) Close() {}
@@ -0,0 +1,130 @@
+package gapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+const defaultDrainTimeout = 30 * time.Second
+
+// tracker counts calls in flight, no call is accepted after draining or
+// closing.
+type tracker struct {
+	mu       sync.Mutex
+	n        int
+	draining bool
+	idle     chan struct{}
+}
+
+func (t *tracker) acquire() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.draining {
+		return false
+	}
+	t.n++
+	return true
+}
+
+func (t *tracker) release() {
+	t.mu.Lock()
+	t.n--
+	if t.n == 0 && t.idle != nil {
+		close(t.idle)
+		t.idle = nil
+	}
+	t.mu.Unlock()
+}
+
+// drain stops accepting calls, the channel is closed when no call is in
+// flight.
+func (t *tracker) drain() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.draining = true
+	return t.idleLocked()
+}
+
+// wait returns a channel closed when no call is in flight, calls are still
+// accepted.
+func (t *tracker) wait() <-chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.idleLocked()
+}
+
+// closeIdle stops accepting calls if no call is in flight.
+func (t *tracker) closeIdle() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.n > 0 {
+		return false
+	}
+	t.draining = true
+	return true
+}
+
+func (t *tracker) idleLocked() <-chan struct{} {
+	if t.n == 0 {
+		idle := make(chan struct{})
+		close(idle)
+		return idle
+	}
+	if t.idle == nil {
+		t.idle = make(chan struct{})
+	}
+	return t.idle
+}
+
+// upstream is a connection to an upstream server, it's closed after calls
+// in flight finished once removed from routes.
+type upstream struct {
+	*grpc.ClientConn
+	server string
+	calls  tracker
+}
+
+// retire closes the connection when calls in flight finished or timeout
+// elapsed. Requests routed by the old router are accepted until then.
+func (u *upstream) retire(timeout time.Duration) {
+	go func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		for !u.calls.closeIdle() {
+			select {
+			case <-u.calls.wait():
+			case <-timer.C:
+				logrus.Warnf("drain connection of %s: timeout after %v", u.server, timeout)
+				u.calls.drain()
+				u.Close()
+				return
+			}
+		}
+		u.Close()
+	}()
+}
+
+// Shutdown stops accepting requests, new requests fail with Unavailable. It
+// waits for requests in flight and closes all connections, which are closed
+// forcibly if ctx is done first. The listener should be closed by
+// http.Server.Shutdown.
+func (s *Server) Shutdown(ctx context.Context) error {
+	var err error
+	select {
+	case <-s.calls.drain():
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	s.routeLock.Lock()
+	defer s.routeLock.Unlock()
+	for _, u := range s.clients {
+		u.Close()
+	}
+	s.clients = nil
+	s.closed = true
+	return err
+}
@@ -0,0 +1,111 @@
+package gapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+func waitShutdown(t *testing.T, cc *grpc.ClientConn) {
+	deadline := time.Now().Add(time.Second)
+	for cc.GetState() != connectivity.Shutdown {
+		if time.Now().After(deadline) {
+			t.Fatal("connection isn't closed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRetireInFlight(t *testing.T) {
+	cc, err := grpc.Dial("127.0.0.1:1", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &upstream{ClientConn: cc, server: "127.0.0.1:1"}
+	if !u.calls.acquire() {
+		t.Fatal("call rejected")
+	}
+	// routes are updated while a call is in flight
+	u.retire(time.Minute)
+	// a request routed by the old router before the update
+	if !u.calls.acquire() {
+		t.Fatal("call routed by the old router rejected")
+	}
+	u.calls.release()
+	time.Sleep(10 * time.Millisecond)
+	if cc.GetState() == connectivity.Shutdown {
+		t.Fatal("connection closed with a call in flight")
+	}
+	u.calls.release()
+	waitShutdown(t, cc)
+	if u.calls.acquire() {
+		t.Fatal("call accepted after the connection closed")
+	}
+}
+
+func TestRetireTimeout(t *testing.T) {
+	cc, err := grpc.Dial("127.0.0.1:1", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := &upstream{ClientConn: cc, server: "127.0.0.1:1"}
+	u.calls.acquire()
+	u.retire(10 * time.Millisecond)
+	waitShutdown(t, cc)
+	if u.calls.acquire() {
+		t.Fatal("call accepted after the connection closed")
+	}
+}
+
+type nopHandler struct{}
+
+func (nopHandler) HandleRequest(call *metadata.Call, ctx *Context) ([]byte, error) {
+	return nil, nil
+}
+
+func (nopHandler) WriteResponse(call *metadata.Call, ctx *Context, data []byte) error {
+	return nil
+}
+
+func TestUpdateRouteInFlight(t *testing.T) {
+	s := NewServer()
+	s.Dial = func(target string) (*grpc.ClientConn, error) {
+		return grpc.Dial(target, grpc.WithInsecure())
+	}
+	s.RegisterHandler("nop", nopHandler{})
+	// answers without calling the upstream
+	s.Use(func(ctx *Context) error {
+		ctx.Response().WriteHeader(http.StatusNoContent)
+		return nil
+	})
+	route := &metadata.Route{
+		Method: http.MethodGet,
+		Path:   "/a",
+		Call: &metadata.Call{
+			Server:  "127.0.0.1:1",
+			Handler: "nop",
+			Name:    "/a.A/A",
+			In:      &metadata.Message{Name: "a.Req"},
+			Out:     &metadata.Message{Name: "a.Res"},
+		},
+	}
+	if err := s.UpdateRoute(&metadata.Metadata{Routes: []*metadata.Route{route}}); err != nil {
+		t.Fatal(err)
+	}
+	// the request is routed by the old router before the update
+	h := s.handlers[routeKey(route)]
+	if err := s.UpdateRoute(&metadata.Metadata{}); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	h.handle(w, httptest.NewRequest(http.MethodGet, "/a", nil), nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status %d, want %d", w.Code, http.StatusNoContent)
+	}
+	waitShutdown(t, h.client.ClientConn)
+}
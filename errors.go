@@ -0,0 +1,133 @@
+package gapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/zhiduoke/gapi/metadata"
+	"github.com/zhiduoke/gapi/proto/pbjson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ErrorWriter may be implemented by a CallHandler to render errors itself.
+type ErrorWriter interface {
+	WriteError(call *metadata.Call, ctx *Context, err error) error
+}
+
+// see https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
+var httpStatus = [...]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+func HTTPStatusFromCode(code codes.Code) int {
+	if int(code) < len(httpStatus) {
+		return httpStatus[code]
+	}
+	return http.StatusInternalServerError
+}
+
+// HTTPError overrides the HTTP status mapped from the code of Err.
+type HTTPError struct {
+	Status int
+	Err    error
+}
+
+func (e *HTTPError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+func (e *HTTPError) GRPCStatus() *status.Status {
+	return status.Convert(e.Err)
+}
+
+func HTTPStatusFromError(err error) int {
+	var he *HTTPError
+	if errors.As(err, &he) {
+		return he.Status
+	}
+	return HTTPStatusFromCode(status.Code(err))
+}
+
+// WriteError writes err as {"code":5,"message":"...","details":[...]}.
+func (s *Server) WriteError(ctx *Context, err error) {
+	st := status.Convert(err)
+	body := appendErrorJSON(nil, st, ctx.resolver)
+	ctx.resp.Header().Set("Content-Type", "application/json")
+	ctx.resp.WriteHeader(HTTPStatusFromError(err))
+	ctx.resp.Write(body)
+}
+
+func appendErrorJSON(b []byte, st *status.Status, resolver metadata.MessageResolver) []byte {
+	b = append(b, `{"code":`...)
+	b = strconv.AppendInt(b, int64(st.Code()), 10)
+	b = append(b, `,"message":`...)
+	msg, _ := json.Marshal(st.Message())
+	b = append(b, msg...)
+	if details := st.Proto().GetDetails(); len(details) > 0 {
+		b = append(b, `,"details":[`...)
+		for i, detail := range details {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendDetailJSON(b, detail, resolver)
+		}
+		b = append(b, ']')
+	}
+	return append(b, '}')
+}
+
+func appendDetailJSON(b []byte, detail *any.Any, resolver metadata.MessageResolver) []byte {
+	if resolver != nil {
+		data, err := proto.Marshal(detail)
+		if err == nil {
+			e := pbjson.NewEncoder(nil)
+			e.EncodeMessage(&metadata.Message{
+				Name:      "google.protobuf.Any",
+				WellKnown: metadata.AnyType,
+				Resolver:  resolver,
+			}, data)
+			if e.Error() == nil {
+				return append(b, e.Bytes()...)
+			}
+		}
+	}
+	// types linked into the binary, e.g. errdetails of the gateway itself
+	if data, err := protojson.Marshal(detail); err == nil {
+		return append(b, data...)
+	}
+	// the type is unknown, keep it encoded
+	data, _ := json.Marshal(struct {
+		Type  string `json:"@type"`
+		Value []byte `json:"value"`
+	}{
+		Type:  detail.GetTypeUrl(),
+		Value: detail.GetValue(),
+	})
+	return append(b, data...)
+}
@@ -0,0 +1,205 @@
+package gapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestHTTPStatusFromError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, http.StatusOK},
+		{status.Error(codes.Canceled, ""), 499},
+		{status.Error(codes.Unknown, ""), http.StatusInternalServerError},
+		{status.Error(codes.InvalidArgument, ""), http.StatusBadRequest},
+		{status.Error(codes.DeadlineExceeded, ""), http.StatusGatewayTimeout},
+		{status.Error(codes.NotFound, ""), http.StatusNotFound},
+		{status.Error(codes.AlreadyExists, ""), http.StatusConflict},
+		{status.Error(codes.PermissionDenied, ""), http.StatusForbidden},
+		{status.Error(codes.ResourceExhausted, ""), http.StatusTooManyRequests},
+		{status.Error(codes.FailedPrecondition, ""), http.StatusBadRequest},
+		{status.Error(codes.Aborted, ""), http.StatusConflict},
+		{status.Error(codes.OutOfRange, ""), http.StatusBadRequest},
+		{status.Error(codes.Unimplemented, ""), http.StatusNotImplemented},
+		{status.Error(codes.Internal, ""), http.StatusInternalServerError},
+		{status.Error(codes.Unavailable, ""), http.StatusServiceUnavailable},
+		{status.Error(codes.DataLoss, ""), http.StatusInternalServerError},
+		{status.Error(codes.Unauthenticated, ""), http.StatusUnauthorized},
+		{status.Error(codes.Code(100), ""), http.StatusInternalServerError},
+		// errors without a status are Unknown
+		{errors.New("plain"), http.StatusInternalServerError},
+		{&HTTPError{Status: http.StatusRequestEntityTooLarge, Err: status.Error(codes.ResourceExhausted, "")}, http.StatusRequestEntityTooLarge},
+	}
+	for _, c := range tests {
+		if s := HTTPStatusFromError(c.err); s != c.want {
+			t.Errorf("%v: status %d, want %d", c.err, s, c.want)
+		}
+	}
+}
+
+type detailResolver map[string]*metadata.Message
+
+func (r detailResolver) FindMessage(name string) *metadata.Message {
+	return r[name]
+}
+
+func stringMsg(name, field string) *metadata.Message {
+	msg := &metadata.Message{
+		Name:   name,
+		Fields: []*metadata.Field{{Tag: 1, Name: field, Kind: metadata.StringKind}},
+	}
+	msg.BakeTagIndex()
+	msg.BakeNameField()
+	return msg
+}
+
+// loadedBadRequest is google.rpc.BadRequest as loaded from descriptors, with
+// fields renamed to tell it from the linked type.
+func loadedBadRequest() *metadata.Message {
+	msg := &metadata.Message{
+		Name: "google.rpc.BadRequest",
+		Fields: []*metadata.Field{{
+			Tag:      1,
+			Name:     "violations",
+			Kind:     metadata.MessageKind,
+			Message:  stringMsg("google.rpc.BadRequest.FieldViolation", "path"),
+			Repeated: true,
+		}},
+	}
+	msg.BakeTagIndex()
+	msg.BakeNameField()
+	return msg
+}
+
+func TestWriteError(t *testing.T) {
+	value := protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), "v")
+	badRequest := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "name", Description: "empty"}},
+	}
+	st := status.New(codes.InvalidArgument, "bad")
+	st, err := st.WithDetails(badRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := st.Proto()
+	p.Details = append(p.Details,
+		&any.Any{TypeUrl: "type.googleapis.com/test.Known", Value: value},
+		&any.Any{TypeUrl: "type.googleapis.com/test.Unknown", Value: value},
+	)
+	withDetails := status.ErrorProto(p)
+
+	tests := []struct {
+		name     string
+		err      error
+		resolver metadata.MessageResolver
+		status   int
+		want     string
+	}{
+		{
+			name:   "no details",
+			err:    status.Error(codes.NotFound, "missing"),
+			status: http.StatusNotFound,
+			want:   `{"code":5,"message":"missing"}`,
+		},
+		{
+			name:   "overridden status",
+			err:    &HTTPError{Status: http.StatusRequestEntityTooLarge, Err: status.Error(codes.ResourceExhausted, "large")},
+			status: http.StatusRequestEntityTooLarge,
+			want:   `{"code":8,"message":"large"}`,
+		},
+		{
+			// linked types are encoded by protojson, unknown ones are kept raw
+			name:   "without a resolver",
+			err:    withDetails,
+			status: http.StatusBadRequest,
+			want: `{"code":3,"message":"bad","details":[
+				{"@type":"type.googleapis.com/google.rpc.BadRequest","fieldViolations":[{"field":"name","description":"empty"}]},
+				{"@type":"type.googleapis.com/test.Known","value":"CgF2"},
+				{"@type":"type.googleapis.com/test.Unknown","value":"CgF2"}]}`,
+		},
+		{
+			// the resolver is preferred to linked types
+			name: "with a resolver",
+			err:  withDetails,
+			resolver: detailResolver{
+				"google.rpc.BadRequest": loadedBadRequest(),
+				"test.Known":            stringMsg("test.Known", "known"),
+			},
+			status: http.StatusBadRequest,
+			want: `{"code":3,"message":"bad","details":[
+				{"@type":"type.googleapis.com/google.rpc.BadRequest","violations":[{"path":"name"}]},
+				{"@type":"type.googleapis.com/test.Known","known":"v"},
+				{"@type":"type.googleapis.com/test.Unknown","value":"CgF2"}]}`,
+		},
+	}
+	for _, c := range tests {
+		rec := httptest.NewRecorder()
+		NewServer().WriteError(&Context{resp: rec, resolver: c.resolver}, c.err)
+		if rec.Code != c.status {
+			t.Errorf("%s: status %d, want %d", c.name, rec.Code, c.status)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: content type %s", c.name, ct)
+		}
+		// protojson randomizes spaces
+		var body, want interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Errorf("%s: %v: %s", c.name, err, rec.Body.Bytes())
+			continue
+		}
+		json.Unmarshal([]byte(c.want), &want)
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("%s: body %s", c.name, rec.Body.Bytes())
+		}
+	}
+}
+
+// errorWriter renders errors as text, unless it fails.
+type errorWriter struct {
+	nopHandler
+	fail bool
+}
+
+func (w errorWriter) WriteError(call *metadata.Call, ctx *Context, err error) error {
+	if w.fail {
+		return errors.New("can't write")
+	}
+	ctx.Response().WriteHeader(http.StatusTeapot)
+	_, werr := ctx.Response().Write([]byte(status.Convert(err).Message()))
+	return werr
+}
+
+func TestErrorWriter(t *testing.T) {
+	err := status.Error(codes.NotFound, "missing")
+	tests := []struct {
+		name   string
+		ch     CallHandler
+		status int
+		body   string
+	}{
+		{"override", errorWriter{}, http.StatusTeapot, "missing"},
+		{"fall back to the server", errorWriter{fail: true}, http.StatusNotFound, `{"code":5,"message":"missing"}`},
+		{"no ErrorWriter", nopHandler{}, http.StatusNotFound, `{"code":5,"message":"missing"}`},
+	}
+	for _, c := range tests {
+		h := &routeHandler{s: NewServer(), ch: c.ch, call: &metadata.Call{}}
+		rec := httptest.NewRecorder()
+		h.writeError(&Context{resp: rec}, err)
+		if rec.Code != c.status || rec.Body.String() != c.body {
+			t.Errorf("%s: %d %s", c.name, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -791,6 +791,439 @@ func (x *RequestBindResp) GetParams() string {
 	return ""
 }
 
+type CountReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	N        int32 `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	Interval int32 `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *CountReq) Reset() {
+	*x = CountReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_http_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountReq) ProtoMessage() {}
+
+func (x *CountReq) ProtoReflect() protoreflect.Message {
+	mi := &file_http_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountReq.ProtoReflect.Descriptor instead.
+func (*CountReq) Descriptor() ([]byte, []int) {
+	return file_http_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CountReq) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *CountReq) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+type CountResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	I int32 `protobuf:"varint,1,opt,name=i,proto3" json:"i,omitempty"`
+}
+
+func (x *CountResp) Reset() {
+	*x = CountResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_http_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResp) ProtoMessage() {}
+
+func (x *CountResp) ProtoReflect() protoreflect.Message {
+	mi := &file_http_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResp.ProtoReflect.Descriptor instead.
+func (*CountResp) Descriptor() ([]byte, []int) {
+	return file_http_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CountResp) GetI() int32 {
+	if x != nil {
+		return x.I
+	}
+	return 0
+}
+
+type EchoReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Uid  string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+}
+
+func (x *EchoReq) Reset() {
+	*x = EchoReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_http_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoReq) ProtoMessage() {}
+
+func (x *EchoReq) ProtoReflect() protoreflect.Message {
+	mi := &file_http_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoReq.ProtoReflect.Descriptor instead.
+func (*EchoReq) Descriptor() ([]byte, []int) {
+	return file_http_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EchoReq) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *EchoReq) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+type EchoResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Uid  string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+}
+
+func (x *EchoResp) Reset() {
+	*x = EchoResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_http_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoResp) ProtoMessage() {}
+
+func (x *EchoResp) ProtoReflect() protoreflect.Message {
+	mi := &file_http_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoResp.ProtoReflect.Descriptor instead.
+func (*EchoResp) Descriptor() ([]byte, []int) {
+	return file_http_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EchoResp) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *EchoResp) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+type UploadReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *UploadReq) Reset() {
+	*x = UploadReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_http_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadReq) ProtoMessage() {}
+
+func (x *UploadReq) ProtoReflect() protoreflect.Message {
+	mi := &file_http_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadReq.ProtoReflect.Descriptor instead.
+func (*UploadReq) Descriptor() ([]byte, []int) {
+	return file_http_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UploadReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadReq) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UploadResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size   int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Chunks int32  `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
+}
+
+func (x *UploadResp) Reset() {
+	*x = UploadResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_http_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadResp) ProtoMessage() {}
+
+func (x *UploadResp) ProtoReflect() protoreflect.Message {
+	mi := &file_http_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadResp.ProtoReflect.Descriptor instead.
+func (*UploadResp) Descriptor() ([]byte, []int) {
+	return file_http_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UploadResp) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadResp) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadResp) GetChunks() int32 {
+	if x != nil {
+		return x.Chunks
+	}
+	return 0
+}
+
+type FlakyReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// fails with UNAVAILABLE for the first n attempts
+	Failures int32 `protobuf:"varint,2,opt,name=failures,proto3" json:"failures,omitempty"`
+}
+
+func (x *FlakyReq) Reset() {
+	*x = FlakyReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_http_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlakyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlakyReq) ProtoMessage() {}
+
+func (x *FlakyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_http_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlakyReq.ProtoReflect.Descriptor instead.
+func (*FlakyReq) Descriptor() ([]byte, []int) {
+	return file_http_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FlakyReq) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *FlakyReq) GetFailures() int32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+type FlakyResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Attempts int32 `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
+}
+
+func (x *FlakyResp) Reset() {
+	*x = FlakyResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_http_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlakyResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlakyResp) ProtoMessage() {}
+
+func (x *FlakyResp) ProtoReflect() protoreflect.Message {
+	mi := &file_http_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlakyResp.ProtoReflect.Descriptor instead.
+func (*FlakyResp) Descriptor() ([]byte, []int) {
+	return file_http_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FlakyResp) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
 type Nest_NestMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -802,7 +1235,7 @@ type Nest_NestMsg struct {
 func (x *Nest_NestMsg) Reset() {
 	*x = Nest_NestMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_http_proto_msgTypes[11]
+		mi := &file_http_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -815,7 +1248,7 @@ func (x *Nest_NestMsg) String() string {
 func (*Nest_NestMsg) ProtoMessage() {}
 
 func (x *Nest_NestMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_http_proto_msgTypes[11]
+	mi := &file_http_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -906,39 +1339,95 @@ var file_http_proto_rawDesc = []byte{
 	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16,
 	0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
 	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x2a, 0x17,
-	0x0a, 0x07, 0x45, 0x6e, 0x75, 0x6d, 0x54, 0x79, 0x70, 0x12, 0x05, 0x0a, 0x01, 0x6d, 0x10, 0x00,
-	0x12, 0x05, 0x0a, 0x01, 0x6e, 0x10, 0x01, 0x32, 0xd4, 0x03, 0x0a, 0x07, 0x44, 0x65, 0x6d, 0x6f,
-	0x41, 0x50, 0x49, 0x12, 0x47, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x12, 0x18, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64,
-	0x65, 0x6d, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x0e, 0xd2, 0xd3,
-	0xee, 0x0b, 0x09, 0x0a, 0x04, 0x2f, 0x61, 0x64, 0x64, 0x40, 0x90, 0x4e, 0x12, 0x4e, 0x0a, 0x04,
-	0x41, 0x64, 0x64, 0x32, 0x12, 0x19, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64,
-	0x65, 0x6d, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x32, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x17, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x41,
-	0x64, 0x64, 0x32, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x12, 0xd2, 0xd3, 0xee, 0x0b, 0x0d, 0x0a,
-	0x05, 0x2f, 0x61, 0x64, 0x64, 0x32, 0x3a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x3f, 0x0a, 0x03,
-	0x53, 0x75, 0x62, 0x12, 0x14, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65,
-	0x6d, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x52, 0x65, 0x73, 0x70,
-	0x22, 0x0b, 0xd2, 0xd3, 0xee, 0x0b, 0x06, 0x0a, 0x04, 0x2f, 0x73, 0x75, 0x62, 0x12, 0x43, 0x0a,
-	0x04, 0x53, 0x75, 0x62, 0x32, 0x12, 0x15, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x52, 0x65, 0x71, 0x32, 0x1a, 0x16, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x52,
-	0x65, 0x73, 0x70, 0x32, 0x22, 0x0c, 0xd2, 0xd3, 0xee, 0x0b, 0x07, 0x0a, 0x05, 0x2f, 0x73, 0x75,
-	0x62, 0x32, 0x12, 0x77, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x69, 0x6e,
-	0x64, 0x12, 0x1c, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f,
-	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x1a,
-	0x1d, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x69, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x22, 0x2b,
-	0xd2, 0xd3, 0xee, 0x0b, 0x26, 0x0a, 0x1a, 0x2f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
-	0x62, 0x69, 0x6e, 0x64, 0x2f, 0x3a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x3a, 0x08, 0x6d, 0x6f, 0x63, 0x6b, 0x5f, 0x63, 0x74, 0x78, 0x1a, 0x31, 0xd2, 0xf7, 0xd6,
-	0x0f, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x68, 0x6f, 0x73, 0x74, 0x3a, 0x31, 0x39, 0x30, 0x39,
-	0x30, 0xe2, 0xf7, 0xd6, 0x0f, 0x08, 0x68, 0x74, 0x74, 0x70, 0x6a, 0x73, 0x6f, 0x6e, 0xe8, 0xf7,
-	0xd6, 0x0f, 0x88, 0x27, 0xf2, 0xf7, 0xd6, 0x0f, 0x05, 0x2f, 0x64, 0x65, 0x6d, 0x6f, 0x42, 0x07,
-	0x5a, 0x05, 0x2e, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x42,
+	0x0a, 0x08, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x12, 0x13, 0x0a, 0x01, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x05, 0x88, 0xc0, 0xa7, 0x17, 0x02, 0x52, 0x01, 0x6e, 0x12,
+	0x21, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x42, 0x05, 0x88, 0xc0, 0xa7, 0x17, 0x02, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x22, 0x19, 0x0a, 0x09, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x0c, 0x0a, 0x01, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x69, 0x22, 0x36, 0x0a,
+	0x07, 0x45, 0x63, 0x68, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x17, 0x0a, 0x03,
+	0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05, 0x88, 0xc0, 0xa7, 0x17, 0x02,
+	0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x30, 0x0a, 0x08, 0x45, 0x63, 0x68, 0x6f, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x33, 0x0a, 0x09, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x4c, 0x0a, 0x0a,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x22, 0x46, 0x0a, 0x08, 0x46, 0x6c,
+	0x61, 0x6b, 0x79, 0x52, 0x65, 0x71, 0x12, 0x17, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x05, 0x88, 0xc0, 0xa7, 0x17, 0x02, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x21, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x42, 0x05, 0x88, 0xc0, 0xa7, 0x17, 0x02, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x73, 0x22, 0x27, 0x0a, 0x09, 0x46, 0x6c, 0x61, 0x6b, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x2a, 0x17, 0x0a, 0x07, 0x45,
+	0x6e, 0x75, 0x6d, 0x54, 0x79, 0x70, 0x12, 0x05, 0x0a, 0x01, 0x6d, 0x10, 0x00, 0x12, 0x05, 0x0a,
+	0x01, 0x6e, 0x10, 0x01, 0x32, 0x95, 0x07, 0x0a, 0x07, 0x44, 0x65, 0x6d, 0x6f, 0x41, 0x50, 0x49,
+	0x12, 0x4f, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x12, 0x18, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f,
+	0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x16, 0xd2, 0xd3, 0xee, 0x0b, 0x11,
+	0x0a, 0x04, 0x2f, 0x61, 0x64, 0x64, 0x40, 0x90, 0x4e, 0x72, 0x06, 0x12, 0x04, 0x2f, 0x61, 0x64,
+	0x64, 0x12, 0x7f, 0x0a, 0x04, 0x41, 0x64, 0x64, 0x32, 0x12, 0x19, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x32, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64,
+	0x65, 0x6d, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x32, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x43, 0xd2,
+	0xd3, 0xee, 0x0b, 0x3e, 0x0a, 0x05, 0x2f, 0x61, 0x64, 0x64, 0x32, 0x3a, 0x04, 0x61, 0x75, 0x74,
+	0x68, 0x3a, 0x20, 0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x28, 0x31, 0x30, 0x2f,
+	0x73, 0x2c, 0x20, 0x6b, 0x65, 0x79, 0x3d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x3a, 0x75,
+	0x69, 0x64, 0x29, 0x62, 0x0d, 0x75, 0x69, 0x64, 0x3d, 0x78, 0x2d, 0x75, 0x73, 0x65, 0x72, 0x2d,
+	0x69, 0x64, 0x12, 0x3f, 0x0a, 0x03, 0x53, 0x75, 0x62, 0x12, 0x14, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x52, 0x65, 0x71, 0x1a,
+	0x15, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x53,
+	0x75, 0x62, 0x52, 0x65, 0x73, 0x70, 0x22, 0x0b, 0xd2, 0xd3, 0xee, 0x0b, 0x06, 0x0a, 0x04, 0x2f,
+	0x73, 0x75, 0x62, 0x12, 0x43, 0x0a, 0x04, 0x53, 0x75, 0x62, 0x32, 0x12, 0x15, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x53, 0x75, 0x62, 0x52, 0x65,
+	0x71, 0x32, 0x1a, 0x16, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d,
+	0x6f, 0x2e, 0x53, 0x75, 0x62, 0x52, 0x65, 0x73, 0x70, 0x32, 0x22, 0x0c, 0xd2, 0xd3, 0xee, 0x0b,
+	0x07, 0x0a, 0x05, 0x2f, 0x73, 0x75, 0x62, 0x32, 0x12, 0x85, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x42, 0x69, 0x6e, 0x64, 0x12, 0x1c, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42,
+	0x69, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x1d, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x69, 0x6e,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x22, 0x39, 0xd2, 0xd3, 0xee, 0x0b, 0x34, 0x0a, 0x1a, 0x2f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x62, 0x69, 0x6e, 0x64, 0x2f, 0x3a, 0x66, 0x72, 0x6f,
+	0x6d, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x3a, 0x08, 0x6d, 0x6f, 0x63, 0x6b, 0x5f, 0x63,
+	0x74, 0x78, 0x5a, 0x0c, 0x58, 0x2d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2d, 0x49, 0x64,
+	0x12, 0x49, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x1a, 0x17, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f,
+	0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x0d, 0xd2, 0xd3, 0xee, 0x0b,
+	0x08, 0x12, 0x06, 0x2f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x4f, 0x0a, 0x04, 0x45,
+	0x63, 0x68, 0x6f, 0x12, 0x15, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65,
+	0x6d, 0x6f, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x14, 0xd2, 0xd3, 0xee, 0x0b, 0x0f, 0x12, 0x05, 0x2f, 0x65, 0x63, 0x68, 0x6f,
+	0x4a, 0x06, 0x77, 0x73, 0x6a, 0x73, 0x6f, 0x6e, 0x28, 0x01, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x05,
+	0x46, 0x6c, 0x61, 0x6b, 0x79, 0x12, 0x16, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x46, 0x6c, 0x61, 0x6b, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x46, 0x6c, 0x61,
+	0x6b, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x16, 0x90, 0x02, 0x02, 0xd2, 0xd3, 0xee, 0x0b, 0x0e,
+	0x12, 0x06, 0x2f, 0x66, 0x6c, 0x61, 0x6b, 0x79, 0x6a, 0x04, 0x08, 0x04, 0x10, 0x0a, 0x12, 0x5b,
+	0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x17, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65,
+	0x71, 0x1a, 0x18, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x64, 0x65, 0x6d, 0x6f,
+	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x22, 0x1c, 0xd2, 0xd3, 0xee,
+	0x0b, 0x17, 0x0a, 0x07, 0x2f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x0c, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x1a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x28, 0x01, 0x1a, 0x5f, 0xd2, 0xf7, 0xd6,
+	0x0f, 0x19, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x3a, 0x2f, 0x2f, 0x2f, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x68, 0x6f, 0x73, 0x74, 0x3a, 0x31, 0x39, 0x30, 0x39, 0x30, 0xe2, 0xf7, 0xd6, 0x0f, 0x08,
+	0x68, 0x74, 0x74, 0x70, 0x6a, 0x73, 0x6f, 0x6e, 0xe8, 0xf7, 0xd6, 0x0f, 0x88, 0x27, 0xf2, 0xf7,
+	0xd6, 0x0f, 0x05, 0x2f, 0x64, 0x65, 0x6d, 0x6f, 0xfa, 0xf7, 0xd6, 0x0f, 0x0f, 0x08, 0x03, 0x2a,
+	0x0b, 0x55, 0x4e, 0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x82, 0xf8, 0xd6, 0x0f,
+	0x0b, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x72, 0x6f, 0x62, 0x69, 0x6e, 0x42, 0x07, 0x5a, 0x05,
+	0x2e, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -954,7 +1443,7 @@ func file_http_proto_rawDescGZIP() []byte {
 }
 
 var file_http_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_http_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_http_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_http_proto_goTypes = []interface{}{
 	(EnumTyp)(0),            // 0: service.demo.EnumTyp
 	(Nest_NestEnum)(0),      // 1: service.demo.Nest.NestEnum
@@ -969,13 +1458,21 @@ var file_http_proto_goTypes = []interface{}{
 	(*SubResp2)(nil),        // 10: service.demo.SubResp2
 	(*RequestBindReq)(nil),  // 11: service.demo.RequestBindReq
 	(*RequestBindResp)(nil), // 12: service.demo.RequestBindResp
-	(*Nest_NestMsg)(nil),    // 13: service.demo.Nest.NestMsg
+	(*CountReq)(nil),        // 13: service.demo.CountReq
+	(*CountResp)(nil),       // 14: service.demo.CountResp
+	(*EchoReq)(nil),         // 15: service.demo.EchoReq
+	(*EchoResp)(nil),        // 16: service.demo.EchoResp
+	(*UploadReq)(nil),       // 17: service.demo.UploadReq
+	(*UploadResp)(nil),      // 18: service.demo.UploadResp
+	(*FlakyReq)(nil),        // 19: service.demo.FlakyReq
+	(*FlakyResp)(nil),       // 20: service.demo.FlakyResp
+	(*Nest_NestMsg)(nil),    // 21: service.demo.Nest.NestMsg
 }
 var file_http_proto_depIdxs = []int32{
 	0,  // 0: service.demo.AddReply.e:type_name -> service.demo.EnumTyp
 	6,  // 1: service.demo.AddReply.f:type_name -> service.demo.Nest
 	6,  // 2: service.demo.Nest.b:type_name -> service.demo.Nest
-	13, // 3: service.demo.Nest.d:type_name -> service.demo.Nest.NestMsg
+	21, // 3: service.demo.Nest.d:type_name -> service.demo.Nest.NestMsg
 	1,  // 4: service.demo.Nest.e:type_name -> service.demo.Nest.NestEnum
 	6,  // 5: service.demo.Nest.NestMsg.c:type_name -> service.demo.Nest
 	2,  // 6: service.demo.DemoAPI.Add:input_type -> service.demo.AddRequest
@@ -983,13 +1480,21 @@ var file_http_proto_depIdxs = []int32{
 	7,  // 8: service.demo.DemoAPI.Sub:input_type -> service.demo.SubReq
 	9,  // 9: service.demo.DemoAPI.Sub2:input_type -> service.demo.SubReq2
 	11, // 10: service.demo.DemoAPI.RequestBind:input_type -> service.demo.RequestBindReq
-	3,  // 11: service.demo.DemoAPI.Add:output_type -> service.demo.AddReply
-	5,  // 12: service.demo.DemoAPI.Add2:output_type -> service.demo.Add2Reply
-	8,  // 13: service.demo.DemoAPI.Sub:output_type -> service.demo.SubResp
-	10, // 14: service.demo.DemoAPI.Sub2:output_type -> service.demo.SubResp2
-	12, // 15: service.demo.DemoAPI.RequestBind:output_type -> service.demo.RequestBindResp
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
+	13, // 11: service.demo.DemoAPI.Count:input_type -> service.demo.CountReq
+	15, // 12: service.demo.DemoAPI.Echo:input_type -> service.demo.EchoReq
+	19, // 13: service.demo.DemoAPI.Flaky:input_type -> service.demo.FlakyReq
+	17, // 14: service.demo.DemoAPI.Upload:input_type -> service.demo.UploadReq
+	3,  // 15: service.demo.DemoAPI.Add:output_type -> service.demo.AddReply
+	5,  // 16: service.demo.DemoAPI.Add2:output_type -> service.demo.Add2Reply
+	8,  // 17: service.demo.DemoAPI.Sub:output_type -> service.demo.SubResp
+	10, // 18: service.demo.DemoAPI.Sub2:output_type -> service.demo.SubResp2
+	12, // 19: service.demo.DemoAPI.RequestBind:output_type -> service.demo.RequestBindResp
+	14, // 20: service.demo.DemoAPI.Count:output_type -> service.demo.CountResp
+	16, // 21: service.demo.DemoAPI.Echo:output_type -> service.demo.EchoResp
+	20, // 22: service.demo.DemoAPI.Flaky:output_type -> service.demo.FlakyResp
+	18, // 23: service.demo.DemoAPI.Upload:output_type -> service.demo.UploadResp
+	15, // [15:24] is the sub-list for method output_type
+	6,  // [6:15] is the sub-list for method input_type
 	6,  // [6:6] is the sub-list for extension type_name
 	6,  // [6:6] is the sub-list for extension extendee
 	0,  // [0:6] is the sub-list for field type_name
@@ -1134,6 +1639,102 @@ func file_http_proto_init() {
 			}
 		}
 		file_http_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_http_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_http_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_http_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_http_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_http_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_http_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlakyReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_http_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlakyResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_http_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Nest_NestMsg); i {
 			case 0:
 				return &v.state
@@ -1152,7 +1753,7 @@ func file_http_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_http_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   12,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1184,6 +1785,10 @@ type DemoAPIClient interface {
 	Sub(ctx context.Context, in *SubReq, opts ...grpc.CallOption) (*SubResp, error)
 	Sub2(ctx context.Context, in *SubReq2, opts ...grpc.CallOption) (*SubResp2, error)
 	RequestBind(ctx context.Context, in *RequestBindReq, opts ...grpc.CallOption) (*RequestBindResp, error)
+	Count(ctx context.Context, in *CountReq, opts ...grpc.CallOption) (DemoAPI_CountClient, error)
+	Echo(ctx context.Context, opts ...grpc.CallOption) (DemoAPI_EchoClient, error)
+	Flaky(ctx context.Context, in *FlakyReq, opts ...grpc.CallOption) (*FlakyResp, error)
+	Upload(ctx context.Context, opts ...grpc.CallOption) (DemoAPI_UploadClient, error)
 }
 
 type demoAPIClient struct {
@@ -1239,6 +1844,112 @@ func (c *demoAPIClient) RequestBind(ctx context.Context, in *RequestBindReq, opt
 	return out, nil
 }
 
+func (c *demoAPIClient) Count(ctx context.Context, in *CountReq, opts ...grpc.CallOption) (DemoAPI_CountClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DemoAPI_serviceDesc.Streams[0], "/service.demo.DemoAPI/Count", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &demoAPICountClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DemoAPI_CountClient interface {
+	Recv() (*CountResp, error)
+	grpc.ClientStream
+}
+
+type demoAPICountClient struct {
+	grpc.ClientStream
+}
+
+func (x *demoAPICountClient) Recv() (*CountResp, error) {
+	m := new(CountResp)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *demoAPIClient) Echo(ctx context.Context, opts ...grpc.CallOption) (DemoAPI_EchoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DemoAPI_serviceDesc.Streams[1], "/service.demo.DemoAPI/Echo", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &demoAPIEchoClient{stream}
+	return x, nil
+}
+
+type DemoAPI_EchoClient interface {
+	Send(*EchoReq) error
+	Recv() (*EchoResp, error)
+	grpc.ClientStream
+}
+
+type demoAPIEchoClient struct {
+	grpc.ClientStream
+}
+
+func (x *demoAPIEchoClient) Send(m *EchoReq) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *demoAPIEchoClient) Recv() (*EchoResp, error) {
+	m := new(EchoResp)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *demoAPIClient) Flaky(ctx context.Context, in *FlakyReq, opts ...grpc.CallOption) (*FlakyResp, error) {
+	out := new(FlakyResp)
+	err := c.cc.Invoke(ctx, "/service.demo.DemoAPI/Flaky", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *demoAPIClient) Upload(ctx context.Context, opts ...grpc.CallOption) (DemoAPI_UploadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DemoAPI_serviceDesc.Streams[2], "/service.demo.DemoAPI/Upload", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &demoAPIUploadClient{stream}
+	return x, nil
+}
+
+type DemoAPI_UploadClient interface {
+	Send(*UploadReq) error
+	CloseAndRecv() (*UploadResp, error)
+	grpc.ClientStream
+}
+
+type demoAPIUploadClient struct {
+	grpc.ClientStream
+}
+
+func (x *demoAPIUploadClient) Send(m *UploadReq) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *demoAPIUploadClient) CloseAndRecv() (*UploadResp, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadResp)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DemoAPIServer is the server API for DemoAPI service.
 type DemoAPIServer interface {
 	Add(context.Context, *AddRequest) (*AddReply, error)
@@ -1246,6 +1957,10 @@ type DemoAPIServer interface {
 	Sub(context.Context, *SubReq) (*SubResp, error)
 	Sub2(context.Context, *SubReq2) (*SubResp2, error)
 	RequestBind(context.Context, *RequestBindReq) (*RequestBindResp, error)
+	Count(*CountReq, DemoAPI_CountServer) error
+	Echo(DemoAPI_EchoServer) error
+	Flaky(context.Context, *FlakyReq) (*FlakyResp, error)
+	Upload(DemoAPI_UploadServer) error
 }
 
 // UnimplementedDemoAPIServer can be embedded to have forward compatible implementations.
@@ -1267,6 +1982,18 @@ func (*UnimplementedDemoAPIServer) Sub2(context.Context, *SubReq2) (*SubResp2, e
 func (*UnimplementedDemoAPIServer) RequestBind(context.Context, *RequestBindReq) (*RequestBindResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RequestBind not implemented")
 }
+func (*UnimplementedDemoAPIServer) Count(*CountReq, DemoAPI_CountServer) error {
+	return status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (*UnimplementedDemoAPIServer) Echo(DemoAPI_EchoServer) error {
+	return status.Errorf(codes.Unimplemented, "method Echo not implemented")
+}
+func (*UnimplementedDemoAPIServer) Flaky(context.Context, *FlakyReq) (*FlakyResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Flaky not implemented")
+}
+func (*UnimplementedDemoAPIServer) Upload(DemoAPI_UploadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Upload not implemented")
+}
 
 func RegisterDemoAPIServer(s *grpc.Server, srv DemoAPIServer) {
 	s.RegisterService(&_DemoAPI_serviceDesc, srv)
@@ -1362,6 +2089,97 @@ func _DemoAPI_RequestBind_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DemoAPI_Count_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CountReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DemoAPIServer).Count(m, &demoAPICountServer{stream})
+}
+
+type DemoAPI_CountServer interface {
+	Send(*CountResp) error
+	grpc.ServerStream
+}
+
+type demoAPICountServer struct {
+	grpc.ServerStream
+}
+
+func (x *demoAPICountServer) Send(m *CountResp) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DemoAPI_Echo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DemoAPIServer).Echo(&demoAPIEchoServer{stream})
+}
+
+type DemoAPI_EchoServer interface {
+	Send(*EchoResp) error
+	Recv() (*EchoReq, error)
+	grpc.ServerStream
+}
+
+type demoAPIEchoServer struct {
+	grpc.ServerStream
+}
+
+func (x *demoAPIEchoServer) Send(m *EchoResp) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *demoAPIEchoServer) Recv() (*EchoReq, error) {
+	m := new(EchoReq)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _DemoAPI_Flaky_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlakyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DemoAPIServer).Flaky(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.demo.DemoAPI/Flaky",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DemoAPIServer).Flaky(ctx, req.(*FlakyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DemoAPI_Upload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DemoAPIServer).Upload(&demoAPIUploadServer{stream})
+}
+
+type DemoAPI_UploadServer interface {
+	SendAndClose(*UploadResp) error
+	Recv() (*UploadReq, error)
+	grpc.ServerStream
+}
+
+type demoAPIUploadServer struct {
+	grpc.ServerStream
+}
+
+func (x *demoAPIUploadServer) SendAndClose(m *UploadResp) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *demoAPIUploadServer) Recv() (*UploadReq, error) {
+	m := new(UploadReq)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _DemoAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "service.demo.DemoAPI",
 	HandlerType: (*DemoAPIServer)(nil),
@@ -1386,7 +2204,28 @@ var _DemoAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RequestBind",
 			Handler:    _DemoAPI_RequestBind_Handler,
 		},
+		{
+			MethodName: "Flaky",
+			Handler:    _DemoAPI_Flaky_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Count",
+			Handler:       _DemoAPI_Count_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Echo",
+			Handler:       _DemoAPI_Echo_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Upload",
+			Handler:       _DemoAPI_Upload_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "http.proto",
 }
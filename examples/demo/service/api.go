@@ -2,15 +2,31 @@ package service
 
 import (
 	"context"
+	"io"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/zhiduoke/gapi/examples/demo/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type API struct {
+	mu       sync.Mutex
+	attempts map[string]int32
 }
 
 func (s *API) RequestBind(ctx context.Context, req *api.RequestBindReq) (*api.RequestBindResp, error) {
+	// echo the forwarded request id
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get("x-request-id"); len(ids) > 0 {
+			grpc.SetHeader(ctx, metadata.Pairs("x-request-id", ids[0]))
+			grpc.SetTrailer(ctx, metadata.Pairs("x-served-by", "demo"))
+		}
+	}
 	return &api.RequestBindResp{
 		Form:   req.FromForm,
 		Ctx:    req.FromCtx,
@@ -29,9 +45,16 @@ func (s *API) Add(ctx context.Context, in *api.AddRequest) (*api.AddReply, error
 
 func (s *API) Add2(ctx context.Context, in *api.Add2Request) (*api.Add2Reply, error) {
 	logrus.Infof("Add2: %s", in)
+	uid := in.UserId
+	// the gateway forwards uid as metadata as well
+	if md, ok := metadata.FromIncomingContext(ctx); ok && uid == "" {
+		if uids := md.Get("x-user-id"); len(uids) > 0 {
+			uid = uids[0]
+		}
+	}
 	return &api.Add2Reply{
 		Sum: in.A + in.B,
-		Uid: in.UserId,
+		Uid: uid,
 	}, nil
 }
 
@@ -44,7 +67,89 @@ func (s *API) Sub(ctx context.Context, in *api.SubReq) (*api.SubResp, error) {
 
 func (s *API) Sub2(ctx context.Context, in *api.SubReq2) (*api.SubResp2, error) {
 	logrus.Infof("Sub2: %s", in)
+	if in.A < in.B {
+		// details are rendered as JSON by the gateway
+		st, err := status.New(codes.OutOfRange, "negative result").WithDetails(in)
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
 	return &api.SubResp2{
 		Result: in.A - in.B,
 	}, nil
 }
+
+func (s *API) Count(in *api.CountReq, stream api.DemoAPI_CountServer) error {
+	logrus.Infof("Count: %s", in)
+	interval := time.Duration(in.Interval) * time.Millisecond
+	for i := int32(0); i < in.N; i++ {
+		if i > 0 && interval > 0 {
+			select {
+			case <-time.After(interval):
+			case <-stream.Context().Done():
+				return stream.Context().Err()
+			}
+		}
+		err := stream.Send(&api.CountResp{I: i})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *API) Echo(stream api.DemoAPI_EchoServer) error {
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		logrus.Infof("Echo: %s", in)
+		if in.Text == "" {
+			return status.Errorf(codes.InvalidArgument, "empty text")
+		}
+		err = stream.Send(&api.EchoResp{Text: in.Text, Uid: in.Uid})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *API) Upload(stream api.DemoAPI_UploadServer) error {
+	resp := &api.UploadResp{}
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if in.Name != "" {
+			resp.Name = in.Name
+		}
+		resp.Size += int64(len(in.Data))
+		resp.Chunks++
+	}
+	logrus.Infof("Upload: %s", resp)
+	return stream.SendAndClose(resp)
+}
+
+func (s *API) Flaky(ctx context.Context, in *api.FlakyReq) (*api.FlakyResp, error) {
+	s.mu.Lock()
+	if s.attempts == nil {
+		s.attempts = map[string]int32{}
+	}
+	s.attempts[in.Key]++
+	n := s.attempts[in.Key]
+	s.mu.Unlock()
+	logrus.Infof("Flaky: %s, attempt %d", in, n)
+	if n <= in.Failures {
+		return nil, status.Errorf(codes.Unavailable, "attempt %d failed", n)
+	}
+	return &api.FlakyResp{Attempts: n}, nil
+}
@@ -1,32 +1,25 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/zhiduoke/gapi"
 	"github.com/zhiduoke/gapi/handler/httpjson"
+	"github.com/zhiduoke/gapi/handler/wsjson"
 	"github.com/zhiduoke/gapi/metadata"
+	"github.com/zhiduoke/gapi/middleware/ratelimit"
 	"github.com/zhiduoke/gapi/proto/pdparser"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-func jsonError(w http.ResponseWriter, code int, msg string) {
-	type Errmsg struct {
-		Code    int    `json:"code"`
-		Message string `json:"message"`
-	}
-	data, _ := json.Marshal(&Errmsg{
-		Code:    code,
-		Message: msg,
-	})
-	w.Write(data)
-}
-
 func loadMetaddata() (*metadata.Metadata, error) {
 	data, err := ioutil.ReadFile("../demo/api/http.pd")
 	if err != nil {
@@ -37,14 +30,7 @@ func loadMetaddata() (*metadata.Metadata, error) {
 
 func main() {
 	s := gapi.NewServer()
-	s.Use(func(ctx *gapi.Context) error {
-		err := ctx.Next()
-		if err != nil {
-			gerr := status.Convert(err)
-			jsonError(ctx.Response(), int(gerr.Code()), gerr.Message())
-		}
-		return nil
-	})
+	s.Breaker = &gapi.BreakerPolicy{}
 	s.RegisterMiddleware("auth", func(ctx *gapi.Context) error {
 		uid := ctx.Request().FormValue("uid")
 		if uid == "" {
@@ -58,23 +44,53 @@ func main() {
 		ctx.Set("from_ctx", time.Now().String())
 		return ctx.Next()
 	})
+	// 10 requests per second by default, limits are configured by use, e.g.
+	// "ratelimit(10/s, key=context:uid)"
+	limits := ratelimit.Config{
+		Limit: ratelimit.Limit{Rate: 10},
+	}
+	s.RegisterMiddlewareFactory("ratelimit", ratelimit.Factory(limits))
+	s.RegisterMiddlewareValidator("ratelimit", ratelimit.Validator(limits))
 	s.RegisterHandler("httpjson", &httpjson.Handler{})
+	s.RegisterHandler("wsjson", &wsjson.Handler{})
 	md, err := loadMetaddata()
 	if err != nil {
 		logrus.Fatalf("loadMetadata: %v", err)
 	}
+	go func() {
+		// curl http://localhost:8081/breakers
+		admin := http.NewServeMux()
+		admin.Handle("/breakers", s.BreakerHandler())
+		logrus.Println("admin server listen on :8081")
+		if err := http.ListenAndServe(":8081", admin); err != nil {
+			logrus.Errorf("serve admin: %v", err)
+		}
+	}()
 	logrus.Println("http server listen on :8080")
-	for _, route := range md.Routes {
-		logrus.Println(route.Method, route.Path)
-	}
-	err = s.UpdateRoute(md)
+	report, err := s.UpdateRouteWithReport(md, gapi.UpdateOptions{})
 	if err != nil {
 		logrus.Fatalf("UpdateRoute: %v", err)
 	}
-	err = http.ListenAndServe(":8080", s)
-	if err != nil {
+	logrus.Printf("routes updated: %s", report)
+	hs := &http.Server{Addr: ":8080", Handler: s}
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		hs.Shutdown(ctx)
+		if err := s.Shutdown(ctx); err != nil {
+			logrus.Errorf("shutdown: %v", err)
+		}
+	}()
+	err = hs.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
 		logrus.Fatalf("serve: %v", err)
 	}
+	<-stopped
 	// a curl test
 	// curl -X POST -H 'from_header: hello' -d 'from_form=xxx' http://localhost:8080/demo/request_bind/sssdsdsd\?from_query\=222ss
 }
@@ -5,12 +5,20 @@ import (
 	"github.com/zhiduoke/gapi/metadata"
 )
 
-type Handler struct{}
+type Handler struct {
+	// Int64AsString writes 64-bit integers of all responses as strings.
+	Int64AsString bool
+	// Strict rejects unknown fields and duplicated fields of JSON bodies.
+	Strict bool
+	// Limits bound requests, they are overridden by limits of routes. A body
+	// too large is rejected with 413, other limits with 400.
+	Limits metadata.Limits
+}
 
 func (h *Handler) HandleRequest(call *metadata.Call, ctx *gapi.Context) ([]byte, error) {
-	return h.handleInput(call.In, ctx)
+	return h.handleInput(call, ctx)
 }
 
 func (h *Handler) WriteResponse(call *metadata.Call, ctx *gapi.Context, data []byte) error {
-	return h.handleOutput(call.Out, data, ctx.Response())
+	return h.handleOutput(call, data, ctx.Response())
 }
@@ -1,33 +1,56 @@
 package httpjson
 
 import (
+	"bytes"
 	"github.com/zhiduoke/gapi"
 	"github.com/zhiduoke/gapi/metadata"
 	"github.com/zhiduoke/gapi/proto/jtop"
 	"github.com/zhiduoke/gapi/proto/kvpb"
-	"io/ioutil"
+	"io"
+	"strings"
 )
 
-func (h *Handler) handleInput(msg *metadata.Message, ctx *gapi.Context) ([]byte, error) {
-	contentType := ctx.Request().Header.Get("Content-Type")
-	var (
-		pb  []byte
-		err error
-	)
-
-	if contentType == "application/json" {
-		// json
-		var body []byte
-		body, err = ioutil.ReadAll(ctx.Request().Body)
-		if err != nil {
+func (h *Handler) handleInput(call *metadata.Call, ctx *gapi.Context) ([]byte, error) {
+	msg := call.In
+	req := ctx.Request()
+	contentType := req.Header.Get("Content-Type")
+	limits := h.Limits.Override(call.Limits)
+	if max := limits.MaxBodyBytes; max > 0 {
+		// form values are parsed from the body as well
+		if err := gapi.LimitBody(ctx, max); err != nil {
 			return nil, err
 		}
-		pb, err = jtop.Encode(msg, body)
-		if err != nil {
+	}
+	var pb []byte
+	// the body of google.api.http bindings may be a field or nothing
+	var bodyField *metadata.Field
+	hasBody := true
+	if b := call.Binding; b != nil {
+		bodyField = b.BodyField
+		hasBody = b.Body != ""
+	}
+
+	if contentType == "application/json" && hasBody {
+		// json, which is decoded while it's read
+		body := req.Body
+		var first [1]byte
+		n, err := io.ReadFull(body, first[:])
+		if err != nil && !(n == 0 && err == io.EOF) {
 			return nil, err
 		}
+		// an empty body sets no fields
+		if n > 0 {
+			var r io.Reader = io.MultiReader(bytes.NewReader(first[:]), body)
+			if bodyField != nil {
+				r = wrapField(bodyField, r)
+			}
+			pb, err = jtop.Options{Strict: h.Strict, Limits: limits}.EncodeReader(msg, r)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
-	httppb, err := kvpb.Encode(msg, &httpKV{ctx: ctx})
+	httppb, err := kvpb.Encode(msg, NewKV(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -35,30 +58,18 @@ func (h *Handler) handleInput(msg *metadata.Message, ctx *gapi.Context) ([]byte,
 	return pb, nil
 }
 
-type httpKV struct {
-	ctx *gapi.Context
-}
-
-func (h *httpKV) GetForm(key string) (string, bool) {
-	v := h.ctx.Request().FormValue(key)
-	return v, len(v) > 0
-}
-
-func (h *httpKV) GetContext(key string) (string, bool) {
-	return h.ctx.Get(key)
-}
-
-func (h *httpKV) GetHeader(key string) (string, bool) {
-	v := h.ctx.Request().Header.Get(key)
-	return v, len(v) > 0
-}
-
-func (h *httpKV) GetQuery(key string) (string, bool) {
-	v := h.ctx.Request().URL.Query().Get(key)
-	return v, len(v) > 0
+// wrapField wraps the value of field as an object.
+func wrapField(field *metadata.Field, value io.Reader) io.Reader {
+	return io.MultiReader(strings.NewReader(`{"`+field.Name+`":`), value, strings.NewReader("}"))
 }
 
-func (h *httpKV) GetParams(key string) (string, bool) {
-	v := h.ctx.Params().ByName(key)
-	return v, len(v) > 0
+// NewKV returns a kvpb.KV which looks up values from the request and the
+// context, the same way HandleRequest does.
+func NewKV(ctx *gapi.Context) kvpb.KV {
+	req := ctx.Request()
+	return gapi.NewKV(ctx, func(key string) (string, bool) {
+		v := req.FormValue(key)
+		_, ok := req.Form[key]
+		return v, ok
+	})
 }
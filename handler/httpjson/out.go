@@ -10,7 +10,9 @@ import (
 
 var encoderPool sync.Pool
 
-func (h *Handler) handleOutput(msg *metadata.Message, data []byte, out http.ResponseWriter) error {
+// handleOutput writes the output message, or the response body field of
+// its binding.
+func (h *Handler) handleOutput(call *metadata.Call, data []byte, out http.ResponseWriter) error {
 	var e *pbjson.Encoder
 	if v := encoderPool.Get(); v != nil {
 		e = v.(*pbjson.Encoder)
@@ -18,7 +20,13 @@ func (h *Handler) handleOutput(msg *metadata.Message, data []byte, out http.Resp
 	} else {
 		e = pbjson.NewEncoder(make([]byte, 0, len(data)))
 	}
-	e.EncodeMessage(msg, data)
+	e.Naming = call.Naming
+	e.Int64AsString = h.Int64AsString
+	if b := call.Binding; b != nil && b.ResponseBody != nil {
+		e.EncodeField(b.ResponseBody, data)
+	} else {
+		e.EncodeMessage(call.Out, data)
+	}
 	err := e.Error()
 	if err != nil {
 		encoderPool.Put(e)
@@ -10,10 +10,19 @@ import (
 )
 
 type Handler struct {
+	// Limits bound requests, they are overridden by limits of routes. A body
+	// too large is rejected with 413.
+	Limits metadata.Limits
 }
 
-func (*Handler) HandleRequest(call *metadata.Call, ctx *gapi.Context) ([]byte, error) {
+func (h *Handler) HandleRequest(call *metadata.Call, ctx *gapi.Context) ([]byte, error) {
 	req := ctx.Request()
+	defer req.Body.Close()
+	if max := h.Limits.Override(call.Limits).MaxBodyBytes; max > 0 {
+		if err := gapi.LimitBody(ctx, max); err != nil {
+			return nil, err
+		}
+	}
 	var headers map[string]string
 	if len(req.Header) > 0 {
 		headers = make(map[string]string, len(req.Header))
@@ -26,7 +35,6 @@ func (*Handler) HandleRequest(call *metadata.Call, ctx *gapi.Context) ([]byte, e
 		}
 	}
 	body, err := ioutil.ReadAll(req.Body)
-	req.Body.Close()
 	if err != nil {
 		return nil, err
 	}
@@ -8,12 +8,19 @@ import (
 )
 
 type Handler struct {
+	// Limits bound requests, they are overridden by limits of routes. A body
+	// too large is rejected with 413.
+	Limits metadata.Limits
 }
 
-func (h *Handler) HandleRequest(_ *metadata.Call, ctx *gapi.Context) ([]byte, error) {
-	body, err := ioutil.ReadAll(ctx.Request().Body)
-	ctx.Request().Body.Close()
-	return body, err
+func (h *Handler) HandleRequest(call *metadata.Call, ctx *gapi.Context) ([]byte, error) {
+	defer ctx.Request().Body.Close()
+	if max := h.Limits.Override(call.Limits).MaxBodyBytes; max > 0 {
+		if err := gapi.LimitBody(ctx, max); err != nil {
+			return nil, err
+		}
+	}
+	return ioutil.ReadAll(ctx.Request().Body)
 }
 
 func (h *Handler) WriteResponse(_ *metadata.Call, ctx *gapi.Context, data []byte) error {
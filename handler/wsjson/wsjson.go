@@ -0,0 +1,242 @@
+// Package wsjson bridges streaming calls to WebSocket connections. Every text
+// or binary message from the client is a JSON encoded input message, every
+// output message is sent to the client as a JSON text message.
+//
+// An empty message or a close frame from the client half-closes the upstream
+// stream, the remaining output messages are still delivered. The connection
+// is closed with a code mapped from the gRPC status once the call finished.
+package wsjson
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+	"github.com/zhiduoke/gapi"
+	"github.com/zhiduoke/gapi/handler/httpjson"
+	"github.com/zhiduoke/gapi/metadata"
+	"github.com/zhiduoke/gapi/proto/jtop"
+	"github.com/zhiduoke/gapi/proto/kvpb"
+	"github.com/zhiduoke/gapi/proto/pbjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errNotStreaming = errors.New("wsjson: only streaming calls are supported")
+
+const closeTimeout = time.Second
+
+type Handler struct {
+	Upgrader websocket.Upgrader
+	// Int64AsString writes 64-bit integers of all messages as strings.
+	Int64AsString bool
+	// Strict rejects unknown fields and duplicated fields of messages.
+	Strict bool
+	// Limits bound each message, they are overridden by limits of routes.
+	Limits metadata.Limits
+}
+
+func (h *Handler) HandleRequest(call *metadata.Call, ctx *gapi.Context) ([]byte, error) {
+	return nil, errNotStreaming
+}
+
+func (h *Handler) WriteResponse(call *metadata.Call, ctx *gapi.Context, data []byte) error {
+	return errNotStreaming
+}
+
+func (h *Handler) HandleStream(call *metadata.Call, ctx *gapi.Context, open gapi.StreamOpener) error {
+	// values bound from the upgrade request are merged into every message
+	bound, err := kvpb.Encode(call.In, httpjson.NewKV(ctx))
+	if err != nil {
+		return err
+	}
+	conn, err := h.Upgrader.Upgrade(ctx.Response(), ctx.Request(), nil)
+	if err != nil {
+		// the upgrader has replied with an error
+		return nil
+	}
+	defer conn.Close()
+	limits := h.Limits.Override(call.Limits)
+	if limits.MaxBodyBytes > 0 {
+		// the connection is closed with 1009 once a message exceeds it
+		conn.SetReadLimit(limits.MaxBodyBytes)
+	}
+	// the request context isn't cancelled by a hijacked connection
+	rpcctx, cancel := context.WithCancel(ctx.Request().Context())
+	defer cancel()
+	stream, err := open(rpcctx)
+	if err != nil {
+		writeClose(conn, err)
+		return nil
+	}
+	b := &bridge{
+		h:      h,
+		opts:   jtop.Options{Strict: h.Strict, Limits: limits},
+		call:   call,
+		conn:   conn,
+		stream: stream,
+		bound:  bound,
+		cancel: cancel,
+		abort:  make(chan error, 1),
+	}
+	go b.readLoop()
+	err = b.writeLoop()
+	select {
+	case aerr := <-b.abort:
+		err = aerr
+	default:
+	}
+	if err != nil && err != errClientGone {
+		logrus.Debugf("stream %s: %v", call.Name, err)
+	}
+	writeClose(conn, err)
+	return nil
+}
+
+var errClientGone = errors.New("client has gone")
+
+type bridge struct {
+	h      *Handler
+	opts   jtop.Options
+	call   *metadata.Call
+	conn   *websocket.Conn
+	stream grpc.ClientStream
+	bound  []byte
+	cancel func()
+	abort  chan error
+
+	closeSend sync.Once
+}
+
+func (b *bridge) halfClose() {
+	b.closeSend.Do(func() {
+		b.stream.CloseSend()
+	})
+}
+
+// readLoop sends client messages to the upstream.
+func (b *bridge) readLoop() {
+	b.conn.SetCloseHandler(func(code int, text string) error {
+		// the close frame is sent back when the call finished
+		b.halfClose()
+		return nil
+	})
+	closed := false
+	for {
+		_, data, err := b.conn.ReadMessage()
+		if err != nil {
+			if _, ok := err.(*websocket.CloseError); !ok {
+				b.fail(errClientGone)
+			}
+			return
+		}
+		if closed {
+			continue
+		}
+		if len(data) == 0 {
+			b.halfClose()
+			closed = true
+			continue
+		}
+		pb, err := b.opts.Encode(b.call.In, data)
+		if err != nil {
+			// errors of the input carry an InvalidArgument status
+			if _, ok := status.FromError(err); !ok {
+				err = status.Error(codes.InvalidArgument, err.Error())
+			}
+			b.fail(err)
+			return
+		}
+		pb = append(pb, b.bound...)
+		if open, err := gapi.SendMsg(b.stream, pb); !open {
+			if err != nil {
+				b.fail(err)
+			}
+			return
+		}
+	}
+}
+
+// fail aborts the call with err.
+func (b *bridge) fail(err error) {
+	select {
+	case b.abort <- err:
+	default:
+	}
+	b.cancel()
+}
+
+// writeLoop sends upstream messages to the client until the call finished.
+func (b *bridge) writeLoop() error {
+	e := pbjson.NewEncoder(nil)
+	e.Naming = b.call.Naming
+	e.Int64AsString = b.h.Int64AsString
+	for {
+		var data []byte
+		err := b.stream.RecvMsg(&data)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		e.Reset()
+		e.EncodeMessage(b.call.Out, data)
+		if err = e.Error(); err != nil {
+			b.cancel()
+			return status.Error(codes.Internal, err.Error())
+		}
+		if err = b.conn.WriteMessage(websocket.TextMessage, e.Bytes()); err != nil {
+			b.cancel()
+			return errClientGone
+		}
+	}
+}
+
+// maxCloseReason is the limit of the reason in a close frame, see RFC 6455 5.5.
+const maxCloseReason = 123
+
+// CloseCode maps the status of a call to a WebSocket close code. Codes with
+// a standard counterpart use it, the others are sent as 4000 + gRPC code.
+func CloseCode(err error) int {
+	if err == nil {
+		return websocket.CloseNormalClosure
+	}
+	switch code := status.Code(err); code {
+	case codes.OK:
+		return websocket.CloseNormalClosure
+	case codes.Canceled:
+		return websocket.CloseGoingAway
+	case codes.Unavailable:
+		return websocket.CloseTryAgainLater
+	case codes.Unknown, codes.Internal, codes.DataLoss:
+		return websocket.CloseInternalServerErr
+	default:
+		return 4000 + int(code)
+	}
+}
+
+func writeClose(conn *websocket.Conn, err error) {
+	if err == errClientGone {
+		return
+	}
+	var reason string
+	if err != nil {
+		reason = status.Convert(err).Message()
+		if len(reason) > maxCloseReason {
+			n := maxCloseReason
+			for n > 0 && !utf8.RuneStart(reason[n]) {
+				n--
+			}
+			reason = reason[:n]
+		}
+	}
+	msg := websocket.FormatCloseMessage(CloseCode(err), reason)
+	conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(closeTimeout))
+}
@@ -0,0 +1,181 @@
+package wsjson
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/zhiduoke/gapi"
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	return v.([]byte), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*[]byte) = append([]byte(nil), data...)
+	return nil
+}
+
+func (rawCodec) String() string {
+	return "raw"
+}
+
+func textMsg(text string) []byte {
+	b := protowire.AppendTag(nil, 1, protowire.BytesType)
+	return protowire.AppendString(b, text)
+}
+
+// chat echoes every message, "end" is sent once the client half-closed and
+// "fail" aborts the call with NotFound.
+func chat(srv interface{}, stream grpc.ServerStream) error {
+	for {
+		var msg []byte
+		err := stream.RecvMsg(&msg)
+		if err == io.EOF {
+			return stream.SendMsg(textMsg("end"))
+		}
+		if err != nil {
+			return err
+		}
+		if string(msg) == string(textMsg("fail")) {
+			return status.Error(codes.NotFound, "no such chat")
+		}
+		if err = stream.SendMsg(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func newTestServer(t *testing.T) string {
+	lis := bufconn.Listen(1 << 20)
+	upstream := grpc.NewServer(grpc.CustomCodec(rawCodec{}), grpc.UnknownServiceHandler(chat))
+	go upstream.Serve(lis)
+	t.Cleanup(upstream.Stop)
+
+	s := gapi.NewServer()
+	s.Dial = func(target string) (*grpc.ClientConn, error) {
+		return grpc.Dial(target, grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}))
+	}
+	s.RegisterHandler("wsjson", &Handler{})
+	msg := &metadata.Message{
+		Name:   "test.Text",
+		Fields: []*metadata.Field{{Tag: 1, Name: "text", Kind: metadata.StringKind}},
+	}
+	msg.BakeTagIndex()
+	msg.BakeNameField()
+	err := s.UpdateRoute(&metadata.Metadata{
+		Routes: []*metadata.Route{{
+			Method: http.MethodGet,
+			Path:   "/chat",
+			Call: &metadata.Call{
+				Server:          "bufnet",
+				Handler:         "wsjson",
+				Name:            "/test.Chat/Chat",
+				In:              msg,
+				Out:             msg,
+				ClientStreaming: true,
+				ServerStreaming: true,
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs := httptest.NewServer(s)
+	t.Cleanup(hs.Close)
+	return "ws" + strings.TrimPrefix(hs.URL, "http") + "/chat"
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return conn
+}
+
+func expectMessage(t *testing.T, conn *websocket.Conn, want string) {
+	t.Helper()
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != want {
+		t.Fatalf("message %s, want %s", data, want)
+	}
+}
+
+func expectClose(t *testing.T, conn *websocket.Conn, code int, text string) {
+	t.Helper()
+	_, _, err := conn.ReadMessage()
+	ce, ok := err.(*websocket.CloseError)
+	if !ok {
+		t.Fatalf("error %v", err)
+	}
+	if ce.Code != code || ce.Text != text {
+		t.Fatalf("closed with %d %q, want %d %q", ce.Code, ce.Text, code, text)
+	}
+}
+
+func TestEcho(t *testing.T) {
+	conn := dial(t, newTestServer(t))
+	for _, text := range []string{"a", "b"} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"text":"`+text+`"}`)); err != nil {
+			t.Fatal(err)
+		}
+		expectMessage(t, conn, `{"text":"`+text+`"}`)
+	}
+}
+
+func TestHalfCloseByEmptyMessage(t *testing.T) {
+	conn := dial(t, newTestServer(t))
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"text":"a"}`))
+	conn.WriteMessage(websocket.TextMessage, nil)
+	// messages after the half-close are dropped
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"text":"b"}`))
+	expectMessage(t, conn, `{"text":"a"}`)
+	expectMessage(t, conn, `{"text":"end"}`)
+	expectClose(t, conn, websocket.CloseNormalClosure, "")
+}
+
+func TestHalfCloseByCloseFrame(t *testing.T) {
+	conn := dial(t, newTestServer(t))
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"text":"a"}`))
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	// output messages are still delivered
+	expectMessage(t, conn, `{"text":"a"}`)
+	expectMessage(t, conn, `{"text":"end"}`)
+	expectClose(t, conn, websocket.CloseNormalClosure, "")
+}
+
+func TestCloseCode(t *testing.T) {
+	conn := dial(t, newTestServer(t))
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"text":"fail"}`))
+	expectClose(t, conn, 4000+int(codes.NotFound), "no such chat")
+
+	conn = dial(t, newTestServer(t))
+	conn.WriteMessage(websocket.TextMessage, []byte(`{"text":1}`))
+	_, _, err := conn.ReadMessage()
+	if ce, ok := err.(*websocket.CloseError); !ok || ce.Code != 4000+int(codes.InvalidArgument) {
+		t.Fatalf("closed with %v", err)
+	}
+}
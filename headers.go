@@ -0,0 +1,277 @@
+package gapi
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/grpc"
+	grpcmd "google.golang.org/grpc/metadata"
+)
+
+const (
+	metadataHeaderPrefix = "Grpc-Metadata-"
+	trailerHeaderPrefix  = "Grpc-Trailer-"
+)
+
+// HeaderPolicy decides which request headers are forwarded to upstreams as
+// gRPC metadata, "Grpc-Metadata-Foo: bar" is always forwarded as "foo: bar".
+// Metadata of upstream responses is written as Grpc-Metadata-* headers and
+// Grpc-Trailer-* headers (or trailers once the body is written).
+type HeaderPolicy struct {
+	// Allow lists headers forwarded under their lower-cased names.
+	Allow []string
+	// Prefixes lists prefixes of forwarded headers, e.g. "X-B3-".
+	Prefixes []string
+	// Rename maps headers to metadata keys.
+	Rename map[string]string
+	// Passthrough lists metadata keys of upstream responses which are written
+	// as headers without a prefix.
+	Passthrough []string
+}
+
+// headerRules is the compiled HeaderPolicy of a route.
+type headerRules struct {
+	// canonical header name => metadata key
+	names map[string]string
+	// canonical prefixes
+	prefixes    []string
+	passthrough map[string]bool
+	// context key => metadata key
+	values map[string]string
+}
+
+// keys managed by grpc or http/2 itself
+var reservedKeys = map[string]bool{
+	"content-type":      true,
+	"content-length":    true,
+	"user-agent":        true,
+	"te":                true,
+	"host":              true,
+	"connection":        true,
+	"keep-alive":        true,
+	"proxy-connection":  true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+func isReservedKey(key string) bool {
+	return key == "" || key[0] == ':' || strings.HasPrefix(key, "grpc-") || reservedKeys[key]
+}
+
+func validKey(key string) bool {
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return key != ""
+}
+
+func (r *headerRules) allow(header string, key string) error {
+	key = strings.ToLower(key)
+	if !validKey(key) || isReservedKey(key) {
+		return fmt.Errorf("invalid metadata key of header %s: %q", header, key)
+	}
+	r.names[textproto.CanonicalMIMEHeaderKey(header)] = key
+	return nil
+}
+
+// addRule parses a rule of forward_headers: "X-Foo", "X-Foo-*" or "X-Foo=key".
+func (r *headerRules) addRule(rule string) error {
+	if i := strings.IndexByte(rule, '='); i >= 0 {
+		return r.allow(rule[:i], rule[i+1:])
+	}
+	if strings.HasSuffix(rule, "*") {
+		prefix := rule[:len(rule)-1]
+		if prefix == "" {
+			return fmt.Errorf("invalid header rule: %q", rule)
+		}
+		r.prefixes = append(r.prefixes, textproto.CanonicalMIMEHeaderKey(prefix))
+		return nil
+	}
+	return r.allow(rule, rule)
+}
+
+// addValue parses a rule of forward_context: "uid" or "uid=x-user-id".
+func (r *headerRules) addValue(rule string) error {
+	name, key := rule, rule
+	if i := strings.IndexByte(rule, '='); i >= 0 {
+		name, key = rule[:i], rule[i+1:]
+	}
+	key = strings.ToLower(key)
+	if name == "" || !validKey(key) || isReservedKey(key) {
+		return fmt.Errorf("invalid context rule: %q", rule)
+	}
+	r.values[name] = key
+	return nil
+}
+
+// compileHeaderRules merges the server policy with rules of the route.
+func compileHeaderRules(policy *HeaderPolicy, values []string, opts *metadata.RouteOptions) (*headerRules, error) {
+	r := &headerRules{
+		names:       map[string]string{},
+		passthrough: map[string]bool{},
+		values:      map[string]string{},
+	}
+	if policy != nil {
+		for _, name := range policy.Allow {
+			if err := r.allow(name, name); err != nil {
+				return nil, err
+			}
+		}
+		for name, key := range policy.Rename {
+			if err := r.allow(name, key); err != nil {
+				return nil, err
+			}
+		}
+		for _, prefix := range policy.Prefixes {
+			r.prefixes = append(r.prefixes, textproto.CanonicalMIMEHeaderKey(prefix))
+		}
+		for _, key := range policy.Passthrough {
+			r.passthrough[strings.ToLower(key)] = true
+		}
+	}
+	for _, rule := range opts.ForwardHeaders {
+		if err := r.addRule(rule); err != nil {
+			return nil, err
+		}
+	}
+	for _, rule := range values {
+		if err := r.addValue(rule); err != nil {
+			return nil, err
+		}
+	}
+	for _, rule := range opts.ForwardContext {
+		if err := r.addValue(rule); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *headerRules) key(name string) (string, bool) {
+	if strings.HasPrefix(name, metadataHeaderPrefix) {
+		return strings.ToLower(name[len(metadataHeaderPrefix):]), true
+	}
+	if key, ok := r.names[name]; ok {
+		return key, true
+	}
+	for _, prefix := range r.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return strings.ToLower(name), true
+		}
+	}
+	return "", false
+}
+
+// outgoing returns the metadata forwarded from request headers.
+func (r *headerRules) outgoing(h http.Header) grpcmd.MD {
+	var md grpcmd.MD
+	for name, vs := range h {
+		key, ok := r.key(name)
+		if !ok || isReservedKey(key) {
+			continue
+		}
+		if md == nil {
+			md = grpcmd.MD{}
+		}
+		if strings.HasSuffix(key, "-bin") {
+			// binary values are base64 encoded in headers
+			for _, v := range vs {
+				b, err := base64.StdEncoding.DecodeString(v)
+				if err != nil {
+					b, err = base64.RawStdEncoding.DecodeString(v)
+				}
+				if err == nil {
+					md[key] = append(md[key], string(b))
+				}
+			}
+			continue
+		}
+		md[key] = append(md[key], vs...)
+	}
+	return md
+}
+
+// writeResponse writes the metadata of upstream responses to h, the name
+// of each header is prefix + key unless it's passed through.
+func (r *headerRules) writeResponse(h http.Header, md grpcmd.MD, prefix string, trailer bool) {
+	for key, vs := range md {
+		if isReservedKey(key) {
+			continue
+		}
+		name := key
+		if !r.passthrough[key] {
+			name = prefix + key
+		}
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		if trailer {
+			name = http.TrailerPrefix + name
+		}
+		bin := strings.HasSuffix(key, "-bin")
+		for _, v := range vs {
+			if bin {
+				v = base64.StdEncoding.EncodeToString([]byte(v))
+			}
+			h.Add(name, v)
+		}
+	}
+}
+
+func (h *routeHandler) outgoingContext(ctx *Context, rpcctx context.Context) context.Context {
+	md := h.headers.outgoing(ctx.req.Header)
+	for name, key := range h.headers.values {
+		// context values are trusted, never take them from headers
+		delete(md, key)
+		if v, ok := ctx.Get(name); ok {
+			if md == nil {
+				md = grpcmd.MD{}
+			}
+			md[key] = []string{v}
+		}
+	}
+	if len(md) == 0 {
+		return rpcctx
+	}
+	if old, ok := grpcmd.FromOutgoingContext(rpcctx); ok {
+		md = grpcmd.Join(old, md)
+	}
+	return grpcmd.NewOutgoingContext(rpcctx, md)
+}
+
+// setHeader saves the header of the upstream response to ctx and writes it
+// to the response.
+func (h *routeHandler) setHeader(ctx *Context, md grpcmd.MD) {
+	if len(md) == 0 {
+		return
+	}
+	ctx.upstreamHeader = md
+	h.headers.writeResponse(ctx.resp.Header(), md, metadataHeaderPrefix, false)
+}
+
+// setTrailer saves the trailer of the upstream response to ctx, it's sent as
+// http trailers if the body has been written.
+func (h *routeHandler) setTrailer(ctx *Context, md grpcmd.MD, wroteBody bool) {
+	if len(md) == 0 {
+		return
+	}
+	ctx.upstreamTrailer = md
+	h.headers.writeResponse(ctx.resp.Header(), md, trailerHeaderPrefix, wroteBody)
+}
+
+func (h *routeHandler) recvHeader(ctx *Context, stream grpc.ClientStream) {
+	md, err := stream.Header()
+	if err == nil {
+		h.setHeader(ctx, md)
+	}
+}
+
+func (h *routeHandler) recvTrailer(ctx *Context, stream grpc.ClientStream, wroteBody bool) {
+	h.setTrailer(ctx, stream.Trailer(), wroteBody)
+}
@@ -0,0 +1,117 @@
+package gapi
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/zhiduoke/gapi/metadata"
+	grpcmd "google.golang.org/grpc/metadata"
+)
+
+func TestOutgoingMetadata(t *testing.T) {
+	policy := &HeaderPolicy{
+		Allow:    []string{"X-Request-Id"},
+		Prefixes: []string{"X-B3-"},
+		Rename:   map[string]string{"Authorization": "auth"},
+	}
+	opts := &metadata.RouteOptions{
+		ForwardHeaders: []string{"X-Tenant=tenant"},
+		ForwardContext: []string{"uid=x-user-id"},
+	}
+	rules, err := compileHeaderRules(policy, []string{"role"}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &routeHandler{headers: rules}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		values map[string]string
+		want   grpcmd.MD
+	}{
+		{
+			name: "policy",
+			header: http.Header{
+				"X-Request-Id":  {"r1"},
+				"X-B3-Traceid":  {"t1"},
+				"X-B3-Spanid":   {"s1"},
+				"Authorization": {"Bearer x"},
+				"X-Tenant":      {"acme"},
+				"X-Other":       {"dropped"},
+			},
+			want: grpcmd.MD{
+				"x-request-id": {"r1"},
+				"x-b3-traceid": {"t1"},
+				"x-b3-spanid":  {"s1"},
+				"auth":         {"Bearer x"},
+				"tenant":       {"acme"},
+			},
+		},
+		{
+			name: "passthrough",
+			header: http.Header{
+				"Grpc-Metadata-Foo":          {"a", "b"},
+				"Grpc-Metadata-Trace-Bin":    {base64.StdEncoding.EncodeToString([]byte{1, 2})},
+				"Grpc-Metadata-Raw-Bin":      {base64.RawStdEncoding.EncodeToString([]byte{1})},
+				"Grpc-Metadata-Content-Type": {"text/plain"},
+				"Grpc-Metadata-Grpc-Timeout": {"1S"},
+			},
+			want: grpcmd.MD{
+				"foo":       {"a", "b"},
+				"trace-bin": {"\x01\x02"},
+				"raw-bin":   {"\x01"},
+			},
+		},
+		{
+			name: "context values replace spoofed headers",
+			header: http.Header{
+				"Grpc-Metadata-X-User-Id": {"spoofed"},
+				"Grpc-Metadata-Role":      {"admin"},
+			},
+			values: map[string]string{"uid": "42"},
+			want:   grpcmd.MD{"x-user-id": {"42"}},
+		},
+		{
+			name:   "nothing forwarded",
+			header: http.Header{"X-Other": {"dropped"}},
+		},
+	}
+	for _, c := range tests {
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.Header = c.header
+		ctx := &Context{req: req}
+		for name, v := range c.values {
+			ctx.Set(name, v)
+		}
+		md, _ := grpcmd.FromOutgoingContext(h.outgoingContext(ctx, context.Background()))
+		if len(md) == 0 && len(c.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(md, c.want) {
+			t.Errorf("%s: metadata %v, want %v", c.name, md, c.want)
+		}
+	}
+}
+
+func TestCompileHeaderRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *HeaderPolicy
+		opts   metadata.RouteOptions
+	}{
+		{"reserved key", &HeaderPolicy{Allow: []string{"Content-Type"}}, metadata.RouteOptions{}},
+		{"grpc key", &HeaderPolicy{Rename: map[string]string{"X-Timeout": "grpc-timeout"}}, metadata.RouteOptions{}},
+		{"invalid key", nil, metadata.RouteOptions{ForwardHeaders: []string{"X-Foo=a b"}}},
+		{"empty prefix", nil, metadata.RouteOptions{ForwardHeaders: []string{"*"}}},
+		{"invalid context rule", nil, metadata.RouteOptions{ForwardContext: []string{"=x-user-id"}}},
+	}
+	for _, c := range tests {
+		if _, err := compileHeaderRules(c.policy, nil, &c.opts); err == nil {
+			t.Errorf("%s: no error", c.name)
+		}
+	}
+}
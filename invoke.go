@@ -1,29 +1,79 @@
 package gapi
 
 import (
+	"fmt"
+
 	"github.com/zhiduoke/gapi/metadata"
-	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type callCodec struct {
 	call *metadata.Call
 	h    CallHandler
+	// grpc hides codec errors in codes.Internal, keep the original one
+	err error
 }
 
 func (c *callCodec) Marshal(v interface{}) ([]byte, error) {
-	cc := v.(*Context)
-	return c.h.HandleRequest(c.call, cc)
+	// requests are encoded by encodeRequest
+	return rawCodec{}.Marshal(v)
+}
+
+// requestError returns the error of a request which can't be decoded.
+func requestError(err error) error {
+	if _, ok := status.FromError(err); !ok {
+		err = status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
 }
 
 func (c *callCodec) Unmarshal(data []byte, v interface{}) error {
+	// written by the caller later, e.g. after the trailer is received
+	if p, ok := v.(*[]byte); ok {
+		*p = data
+		return nil
+	}
 	cc := v.(*Context)
-	return c.h.WriteResponse(c.call, cc, data)
+	err := c.h.WriteResponse(c.call, cc, data)
+	if err != nil {
+		c.err = err
+	}
+	return err
+}
+
+// wrap returns the original error of the codec if any.
+func (c *callCodec) wrap(err error) error {
+	if err != nil && c.err != nil {
+		return c.err
+	}
+	return err
 }
 
 func (c *callCodec) Name() string {
 	return "call"
 }
 
-func defaultDial(server string) (*grpc.ClientConn, error) {
-	return grpc.Dial(server, grpc.WithInsecure())
+// rawCodec passes encoded messages through, it's used by StreamHandler.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	data, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("raw codec: unexpected message type %T", v)
+	}
+	return data, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	p, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("raw codec: unexpected message type %T", v)
+	}
+	*p = data
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "raw"
 }
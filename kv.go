@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"net/textproto"
+
+	"github.com/zhiduoke/gapi/proto/kvpb"
+)
+
+// NewKV returns a kvpb.KV which looks up values from the request and the
+// context, form looks up the values of GetForm.
+func NewKV(ctx *Context, form func(key string) (string, bool)) kvpb.KV {
+	return &requestKV{ctx: ctx, form: form}
+}
+
+type requestKV struct {
+	ctx  *Context
+	form func(key string) (string, bool)
+}
+
+func (kv *requestKV) GetForm(key string) (string, bool) {
+	return kv.form(key)
+}
+
+func (kv *requestKV) GetContext(key string) (string, bool) {
+	return kv.ctx.Get(key)
+}
+
+func (kv *requestKV) GetHeader(key string) (string, bool) {
+	vs, ok := kv.ctx.req.Header[textproto.CanonicalMIMEHeaderKey(key)]
+	if !ok || len(vs) == 0 {
+		return "", false
+	}
+	return vs[0], true
+}
+
+func (kv *requestKV) GetQuery(key string) (string, bool) {
+	vs, ok := kv.ctx.req.URL.Query()[key]
+	if !ok || len(vs) == 0 {
+		return "", false
+	}
+	return vs[0], true
+}
+
+func (kv *requestKV) GetParams(key string) (string, bool) {
+	for _, p := range kv.ctx.params {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
@@ -2,7 +2,10 @@ package metadata
 
 import (
 	"sort"
+	"strconv"
 	"time"
+
+	"google.golang.org/grpc/codes"
 )
 
 type TypeKind int
@@ -30,6 +33,36 @@ const (
 	MaxTypeKind
 )
 
+var kindNames = [...]string{
+	InvalidType:  "invalid",
+	Int32Kind:    "int32",
+	Uint32Kind:   "uint32",
+	Int64Kind:    "int64",
+	Uint64Kind:   "uint64",
+	BoolKind:     "bool",
+	FloatKind:    "float",
+	DoubleKind:   "double",
+	Fixed32Kind:  "fixed32",
+	Fixed64Kind:  "fixed64",
+	EnumKind:     "enum",
+	Sfixed32Kind: "sfixed32",
+	Sfixed64Kind: "sfixed64",
+	Sint32Kind:   "sint32",
+	Sint64Kind:   "sint64",
+	StringKind:   "string",
+	BytesKind:    "bytes",
+	MessageKind:  "message",
+	MapKind:      "map",
+}
+
+// String returns the name of the kind in .proto files.
+func (k TypeKind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "kind(" + strconv.Itoa(int(k)) + ")"
+}
+
 const (
 	FromDefault = iota
 	FromContext
@@ -39,32 +72,177 @@ const (
 )
 
 type FieldOptions struct {
-	OmitEmpty bool
-	RawData   bool
-	Validate  bool
-	Bind      int
+	OmitEmpty     bool
+	RawData       bool
+	Validate      bool
+	Bind          int
+	EnumAsString  bool
+	Int64AsString bool
 }
 
+// Naming is the style of JSON keys written by pbjson, keys of any style are
+// accepted by jtop.
+type Naming int
+
+const (
+	// OriginalNaming uses field names in .proto files.
+	OriginalNaming Naming = iota
+	// JSONNaming uses json_name of fields, which is lowerCamelCase unless it's
+	// set explicitly.
+	JSONNaming
+	// CamelCaseNaming uses lowerCamelCase of field names.
+	CamelCaseNaming
+)
+
 type Field struct {
-	Tag      int
-	Name     string
-	Kind     TypeKind
-	Message  *Message
-	Repeated bool
+	Tag  int
+	Name string
+	// JSONName and CamelName are keys of other naming styles, Name is used
+	// if they're empty.
+	JSONName  string
+	CamelName string
+	Kind      TypeKind
+	Message   *Message
+	Enum      *Enum
+	Oneof     *Oneof
+	Repeated  bool
+	// Optional is set for proto3 optional fields, which have explicit presence
+	Optional bool
 	Options  FieldOptions
 }
 
+// Key returns the JSON key of the field in the naming style.
+func (f *Field) Key(naming Naming) string {
+	switch {
+	case naming == JSONNaming && f.JSONName != "":
+		return f.JSONName
+	case naming == CamelCaseNaming && f.CamelName != "":
+		return f.CamelName
+	}
+	return f.Name
+}
+
+type Oneof struct {
+	Name   string
+	Fields []*Field
+}
+
+type EnumValue struct {
+	Name   string
+	Number int32
+}
+
+type Enum struct {
+	Name        string
+	Values      []*EnumValue
+	nameValue   []*EnumValue
+	numberValue []*EnumValue
+}
+
+func (e *Enum) BakeValueIndex() {
+	values := e.Values
+	nameValue := make([]*EnumValue, len(values))
+	copy(nameValue, values)
+	sort.Slice(nameValue, func(i, j int) bool {
+		return nameValue[i].Name < nameValue[j].Name
+	})
+	e.nameValue = nameValue
+	// keep declaration order for aliased numbers, the first one wins
+	numberValue := make([]*EnumValue, len(values))
+	copy(numberValue, values)
+	sort.SliceStable(numberValue, func(i, j int) bool {
+		return numberValue[i].Number < numberValue[j].Number
+	})
+	e.numberValue = numberValue
+}
+
+func (e *Enum) GetValue(name string) *EnumValue {
+	l, r := 0, len(e.nameValue)-1
+	for l <= r {
+		mid := (l + r) / 2
+		v := e.nameValue[mid]
+		if v.Name == name {
+			return v
+		} else if v.Name > name {
+			r = mid - 1
+		} else {
+			l = mid + 1
+		}
+	}
+	return nil
+}
+
+func (e *Enum) FindNumber(num int32) *EnumValue {
+	values := e.numberValue
+	i := sort.Search(len(values), func(i int) bool {
+		return values[i].Number >= num
+	})
+	if i < len(values) && values[i].Number == num {
+		return values[i]
+	}
+	return nil
+}
+
+type WellKnownType int
+
+const (
+	NotWellKnown WellKnownType = iota
+	TimestampType
+	DurationType
+	DoubleValueType
+	FloatValueType
+	Int64ValueType
+	UInt64ValueType
+	Int32ValueType
+	UInt32ValueType
+	BoolValueType
+	StringValueType
+	BytesValueType
+	StructType
+	ValueType
+	ListValueType
+	AnyType
+)
+
+// IsWrapper reports whether t is one of google/protobuf/wrappers.proto.
+func (t WellKnownType) IsWrapper() bool {
+	return t >= DoubleValueType && t <= BytesValueType
+}
+
+// MessageResolver looks up messages by full name (without leading dot),
+// it's used to resolve the packed type of google.protobuf.Any.
+type MessageResolver interface {
+	FindMessage(name string) *Message
+}
+
+// Resolvers looks up messages from each resolver in order.
+type Resolvers []MessageResolver
+
+func (rs Resolvers) FindMessage(name string) *Message {
+	for _, r := range rs {
+		if msg := r.FindMessage(name); msg != nil {
+			return msg
+		}
+	}
+	return nil
+}
+
 type MessageOptions struct {
-	Flat      bool
-	ExtraInfo interface{}
+	Flat           bool
+	EnumsAsString  bool
+	Int64sAsString bool
+	ExtraInfo      interface{}
 }
 
 type Message struct {
 	Name      string
 	Fields    []*Field
+	Oneofs    []*Oneof
 	tagIndex  []int
-	nameField []*Field
+	nameField []namedField
 	Options   MessageOptions
+	WellKnown WellKnownType
+	Resolver  MessageResolver
 }
 
 func (m *Message) BakeTagIndex() {
@@ -126,27 +304,52 @@ func (m *Message) TagIndex(tag int) int {
 	return -1
 }
 
+type namedField struct {
+	name  string
+	field *Field
+}
+
+// BakeNameField indexes fields by names of all naming styles, the original
+// names take precedence over the others.
 func (m *Message) BakeNameField() {
 	if len(m.Fields) == 0 {
 		return
 	}
 	fields := m.Fields
-	nameField := make([]*Field, len(fields))
-	copy(nameField, fields)
-	sort.Slice(nameField, func(i, j int) bool {
-		return nameField[i].Name < nameField[j].Name
+	nameField := make([]namedField, 0, len(fields))
+	for _, f := range fields {
+		nameField = append(nameField, namedField{name: f.Name, field: f})
+	}
+	for _, f := range fields {
+		for _, name := range [...]string{f.JSONName, f.CamelName} {
+			if name != "" && name != f.Name {
+				nameField = append(nameField, namedField{name: name, field: f})
+			}
+		}
+	}
+	sort.SliceStable(nameField, func(i, j int) bool {
+		return nameField[i].name < nameField[j].name
 	})
-	m.nameField = nameField
+	// drop duplicated names, the first one wins
+	n := 0
+	for i, nf := range nameField {
+		if i > 0 && nf.name == nameField[n-1].name {
+			continue
+		}
+		nameField[n] = nf
+		n++
+	}
+	m.nameField = nameField[:n]
 }
 
 func (m *Message) GetField(fieldName string) *Field {
 	l, r := 0, len(m.nameField)-1
 	for l <= r {
 		mid := (l + r) / 2
-		field := m.nameField[mid]
-		if field.Name == fieldName {
-			return field
-		} else if field.Name > fieldName {
+		nf := m.nameField[mid]
+		if nf.name == fieldName {
+			return nf.field
+		} else if nf.name > fieldName {
 			r = mid - 1
 		} else {
 			l = mid + 1
@@ -155,17 +358,103 @@ func (m *Message) GetField(fieldName string) *Field {
 	return nil
 }
 
+// Upload maps the request body onto a client-streaming call.
+type Upload struct {
+	// ChunkField is a bytes field of Call.In which carries the body.
+	ChunkField *Field
+	ChunkSize  int
+	// FormFile is the form field of the file in a multipart request.
+	FormFile string
+}
+
+// Limits bound the size of requests, a zero limit is unlimited.
+type Limits struct {
+	MaxBodyBytes    int64
+	MaxDepth        int
+	MaxArrayLength  int
+	MaxMapEntries   int
+	MaxStringLength int
+}
+
+// Override returns l with the limits set in o replaced.
+func (l Limits) Override(o Limits) Limits {
+	if o.MaxBodyBytes != 0 {
+		l.MaxBodyBytes = o.MaxBodyBytes
+	}
+	if o.MaxDepth != 0 {
+		l.MaxDepth = o.MaxDepth
+	}
+	if o.MaxArrayLength != 0 {
+		l.MaxArrayLength = o.MaxArrayLength
+	}
+	if o.MaxMapEntries != 0 {
+		l.MaxMapEntries = o.MaxMapEntries
+	}
+	if o.MaxStringLength != 0 {
+		l.MaxStringLength = o.MaxStringLength
+	}
+	return l
+}
+
+// RetryPolicy retries or hedges unary calls of idempotent methods.
+type RetryPolicy struct {
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+	RetryableCodes    []codes.Code
+	// HedgingDelay enables hedging if it's positive.
+	HedgingDelay time.Duration
+}
+
+func (p *RetryPolicy) Retryable(code codes.Code) bool {
+	for _, c := range p.RetryableCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Binding is an HTTP binding of google.api.http.
+type Binding struct {
+	Template *PathTemplate
+	// Variables are fields of Call.In bound by Template.Variables, params of
+	// the route are named by their FieldPath.
+	Variables [][]*Field
+	// Body is "*" for the whole request message, a field name of Call.In
+	// or "" if the request has no body.
+	Body      string
+	BodyField *Field
+	// ResponseBody is the field of Call.Out written as the response body,
+	// the whole message is written if it's nil.
+	ResponseBody *Field
+}
+
 type Call struct {
-	Server  string
-	Handler string
-	Name    string
-	In      *Message
-	Out     *Message
-	Timeout time.Duration
+	Server          string
+	Balancer        string
+	Handler         string
+	Name            string
+	In              *Message
+	Out             *Message
+	Timeout         time.Duration
+	ClientStreaming bool
+	ServerStreaming bool
+	Upload          *Upload
+	Retry           *RetryPolicy
+	Binding         *Binding
+	Naming          Naming
+	// Limits override limits of the handler.
+	Limits Limits
 }
 
 type RouteOptions struct {
-	Middlewares []string
+	Middlewares []*Middleware
+	// ForwardHeaders are rules of request headers forwarded as metadata.
+	ForwardHeaders []string
+	// ForwardContext are rules of context values forwarded as metadata.
+	ForwardContext []string
 }
 
 type Route struct {
@@ -177,4 +466,6 @@ type Route struct {
 
 type Metadata struct {
 	Routes []*Route
+	// Resolver finds messages of the loaded descriptors, e.g. error details.
+	Resolver MessageResolver
 }
@@ -0,0 +1,149 @@
+package metadata
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Middleware is an entry of use: a name with optional arguments, e.g.
+// ratelimit(100/s, key=context:uid). Values containing commas, parentheses
+// or spaces can be quoted: auth(scope="a, b").
+type Middleware struct {
+	Name string
+	// Args are positional arguments.
+	Args []string
+	// Options are named arguments.
+	Options map[string]string
+}
+
+// HasArgs reports whether any argument is given.
+func (m *Middleware) HasArgs() bool {
+	return len(m.Args) > 0 || len(m.Options) > 0
+}
+
+// Get returns the named argument.
+func (m *Middleware) Get(name string) (string, bool) {
+	v, ok := m.Options[name]
+	return v, ok
+}
+
+func (m *Middleware) String() string {
+	if !m.HasArgs() {
+		return m.Name
+	}
+	args := make([]string, 0, len(m.Args)+len(m.Options))
+	for _, arg := range m.Args {
+		args = append(args, quoteArg(arg))
+	}
+	names := make([]string, 0, len(m.Options))
+	for name := range m.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, name+"="+quoteArg(m.Options[name]))
+	}
+	return m.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func quoteArg(s string) string {
+	if s == "" || strings.ContainsAny(s, ",()=\" \t") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func isIdent(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+			c == '_' || c == '-' || c == '.') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// ParseMiddleware parses an entry of use.
+func ParseMiddleware(s string) (*Middleware, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexByte(s, '(')
+	if i < 0 {
+		if !isIdent(s) {
+			return nil, fmt.Errorf("invalid middleware: %q", s)
+		}
+		return &Middleware{Name: s}, nil
+	}
+	m := &Middleware{Name: strings.TrimSpace(s[:i])}
+	if !isIdent(m.Name) || !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("invalid middleware: %q", s)
+	}
+	args, err := splitArgs(s[i+1 : len(s)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments of middleware %s: %v", m.Name, err)
+	}
+	for _, arg := range args {
+		name, value := "", arg
+		if j := strings.IndexByte(arg, '='); j > 0 && arg[0] != '"' {
+			name, value = strings.TrimSpace(arg[:j]), strings.TrimSpace(arg[j+1:])
+			if !isIdent(name) {
+				return nil, fmt.Errorf("invalid argument of middleware %s: %q", m.Name, arg)
+			}
+		}
+		if strings.HasPrefix(value, `"`) {
+			value, err = strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid argument of middleware %s: %q", m.Name, arg)
+			}
+		}
+		if name == "" {
+			m.Args = append(m.Args, value)
+			continue
+		}
+		if m.Options == nil {
+			m.Options = map[string]string{}
+		}
+		if _, ok := m.Options[name]; ok {
+			return nil, fmt.Errorf("duplicated argument of middleware %s: %s", m.Name, name)
+		}
+		m.Options[name] = value
+	}
+	return m, nil
+}
+
+// splitArgs splits arguments by commas outside of quotes.
+func splitArgs(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var (
+		args   []string
+		start  int
+		quoted bool
+	)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case !quoted && (c == '(' || c == ')'):
+			return nil, fmt.Errorf("unexpected %q", c)
+		case !quoted && c == ',':
+			args = append(args, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	args = append(args, strings.TrimSpace(s[start:]))
+	for _, arg := range args {
+		if arg == "" {
+			return nil, fmt.Errorf("empty argument")
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,151 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+)
+
+type SegmentKind int
+
+const (
+	LiteralSegment SegmentKind = iota
+	// WildcardSegment (*) matches a segment.
+	WildcardSegment
+	// DeepWildcardSegment (**) matches the rest of the path.
+	DeepWildcardSegment
+)
+
+type Segment struct {
+	Kind    SegmentKind
+	Literal string
+}
+
+// TemplateVariable captures Segments[Start:End] into the field at FieldPath.
+type TemplateVariable struct {
+	FieldPath string
+	Start     int
+	End       int
+}
+
+// PathTemplate is a path template of google.api.http, e.g.
+// /v1/{name=projects/*/books/*}:publish.
+type PathTemplate struct {
+	Segments  []Segment
+	Verb      string
+	Variables []*TemplateVariable
+}
+
+// ParseTemplate parses a path template, see
+// https://github.com/googleapis/googleapis/blob/master/google/api/http.proto
+func ParseTemplate(path string) (*PathTemplate, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("template %s must start with '/'", path)
+	}
+	s := path[1:]
+	// the verb follows the last segment
+	depth, verb := 0, -1
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '/':
+			if depth == 0 {
+				verb = -1
+			}
+		case ':':
+			if depth == 0 && verb < 0 {
+				verb = i
+			}
+		}
+	}
+	t := &PathTemplate{}
+	if verb >= 0 {
+		t.Verb = s[verb+1:]
+		s = s[:verb]
+		if t.Verb == "" || strings.ContainsAny(t.Verb, "/{}*:") {
+			return nil, fmt.Errorf("invalid verb of template %s", path)
+		}
+	}
+	names := map[string]bool{}
+	for len(s) > 0 {
+		if s[0] == '{' {
+			end := strings.IndexByte(s, '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed variable in template %s", path)
+			}
+			name, pattern := s[1:end], "*"
+			if i := strings.IndexByte(name, '='); i >= 0 {
+				name, pattern = name[:i], name[i+1:]
+			}
+			if !validFieldPath(name) || strings.ContainsAny(pattern, "{=") {
+				return nil, fmt.Errorf("invalid variable %s in template %s", s[:end+1], path)
+			}
+			if names[name] {
+				return nil, fmt.Errorf("duplicated variable %s in template %s", name, path)
+			}
+			names[name] = true
+			v := &TemplateVariable{FieldPath: name, Start: len(t.Segments)}
+			for _, seg := range strings.Split(pattern, "/") {
+				if err := t.addSegment(seg); err != nil {
+					return nil, fmt.Errorf("%v in template %s", err, path)
+				}
+			}
+			v.End = len(t.Segments)
+			t.Variables = append(t.Variables, v)
+			s = s[end+1:]
+		} else {
+			end := strings.IndexByte(s, '/')
+			if end < 0 {
+				end = len(s)
+			}
+			if err := t.addSegment(s[:end]); err != nil {
+				return nil, fmt.Errorf("%v in template %s", err, path)
+			}
+			s = s[end:]
+		}
+		if len(s) == 0 {
+			break
+		}
+		if s[0] != '/' || len(s) == 1 {
+			return nil, fmt.Errorf("invalid template %s", path)
+		}
+		s = s[1:]
+	}
+	for i, seg := range t.Segments {
+		if seg.Kind == DeepWildcardSegment && i != len(t.Segments)-1 {
+			return nil, fmt.Errorf("** must be the last segment in template %s", path)
+		}
+	}
+	return t, nil
+}
+
+func (t *PathTemplate) addSegment(seg string) error {
+	switch {
+	case seg == "*":
+		t.Segments = append(t.Segments, Segment{Kind: WildcardSegment})
+	case seg == "**":
+		t.Segments = append(t.Segments, Segment{Kind: DeepWildcardSegment})
+	case seg == "" || strings.ContainsAny(seg, "{}*=:"):
+		return fmt.Errorf("invalid segment %q", seg)
+	default:
+		t.Segments = append(t.Segments, Segment{Kind: LiteralSegment, Literal: seg})
+	}
+	return nil
+}
+
+func validFieldPath(s string) bool {
+	for _, name := range strings.Split(s, ".") {
+		if name == "" {
+			return false
+		}
+		for i := 0; i < len(name); i++ {
+			c := name[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || i > 0 && c >= '0' && c <= '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
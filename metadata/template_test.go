@@ -0,0 +1,78 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTemplate(t *testing.T) {
+	lit := func(s string) Segment {
+		return Segment{Kind: LiteralSegment, Literal: s}
+	}
+	wild := Segment{Kind: WildcardSegment}
+	deep := Segment{Kind: DeepWildcardSegment}
+	tests := []struct {
+		path string
+		want *PathTemplate
+	}{
+		{"/", &PathTemplate{}},
+		{"/v1/books", &PathTemplate{Segments: []Segment{lit("v1"), lit("books")}}},
+		{"/v1/{name}", &PathTemplate{
+			Segments:  []Segment{lit("v1"), wild},
+			Variables: []*TemplateVariable{{FieldPath: "name", Start: 1, End: 2}},
+		}},
+		{"/v1/{name=projects/*/books/*}", &PathTemplate{
+			Segments:  []Segment{lit("v1"), lit("projects"), wild, lit("books"), wild},
+			Variables: []*TemplateVariable{{FieldPath: "name", Start: 1, End: 5}},
+		}},
+		{"/v1/{book.name=shelves/*}/{id}", &PathTemplate{
+			Segments: []Segment{lit("v1"), lit("shelves"), wild, wild},
+			Variables: []*TemplateVariable{
+				{FieldPath: "book.name", Start: 1, End: 3},
+				{FieldPath: "id", Start: 3, End: 4},
+			},
+		}},
+		{"/v1/files/**", &PathTemplate{Segments: []Segment{lit("v1"), lit("files"), deep}}},
+		{"/v1/{path=files/**}", &PathTemplate{
+			Segments:  []Segment{lit("v1"), lit("files"), deep},
+			Variables: []*TemplateVariable{{FieldPath: "path", Start: 1, End: 3}},
+		}},
+		{"/v1/{name}:cancel", &PathTemplate{
+			Segments:  []Segment{lit("v1"), wild},
+			Verb:      "cancel",
+			Variables: []*TemplateVariable{{FieldPath: "name", Start: 1, End: 2}},
+		}},
+		{"/v1/*:undelete", &PathTemplate{Segments: []Segment{lit("v1"), wild}, Verb: "undelete"}},
+	}
+	for _, c := range tests {
+		got, err := ParseTemplate(c.path)
+		if err != nil {
+			t.Errorf("%s: %v", c.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %+v, want %+v", c.path, got, c.want)
+		}
+	}
+
+	invalid := []string{
+		"v1/books",
+		"/v1//books",
+		"/v1/books/",
+		"/v1/{name",
+		"/v1/{name}}",
+		"/v1/{1name}",
+		"/v1/{a.}",
+		"/v1/{name=projects/{id}}",
+		"/v1/{a}/{a}",
+		"/v1/**/books",
+		"/v1/{name}:",
+		"/v1/books:a:b",
+		"/v1/bo*ks",
+	}
+	for _, path := range invalid {
+		if _, err := ParseTemplate(path); err == nil {
+			t.Errorf("%s: no error", path)
+		}
+	}
+}
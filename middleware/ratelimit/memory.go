@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type entry struct {
+	// token bucket
+	tokens float64
+	last   time.Time
+	// sliding window
+	window int64
+	count  int
+	prev   int
+
+	expire time.Time
+}
+
+// MemoryStore keeps keys in the process, idle keys are evicted.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: map[string]*entry{},
+	}
+}
+
+func (s *MemoryStore) Allow(key string, limit Limit, now time.Time) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+	e := s.entries[key]
+	if e == nil {
+		e = &entry{
+			tokens: float64(limit.Burst),
+			last:   now,
+		}
+		s.entries[key] = e
+	}
+	// both states are reset after two periods
+	e.expire = now.Add(2 * limit.Period)
+	var (
+		ok   bool
+		wait time.Duration
+	)
+	switch limit.Algorithm {
+	case SlidingWindow:
+		ok, wait = e.slidingWindow(limit, now)
+	default:
+		ok, wait = e.tokenBucket(limit, now)
+	}
+	return ok, wait, nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	s.lastSweep = now
+	for key, e := range s.entries {
+		if now.After(e.expire) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+func (e *entry) tokenBucket(limit Limit, now time.Time) (bool, time.Duration) {
+	// tokens per nanosecond
+	rate := float64(limit.Rate) / float64(limit.Period)
+	if elapsed := now.Sub(e.last); elapsed > 0 {
+		e.tokens += float64(elapsed) * rate
+		if e.tokens > float64(limit.Burst) {
+			e.tokens = float64(limit.Burst)
+		}
+		e.last = now
+	}
+	if e.tokens >= 1 {
+		e.tokens--
+		return true, 0
+	}
+	return false, time.Duration(math.Ceil((1 - e.tokens) / rate))
+}
+
+// slidingWindow estimates requests of the last period by weighting the
+// previous window, see https://blog.cloudflare.com/counting-things-a-lot-of-different-things/
+func (e *entry) slidingWindow(limit Limit, now time.Time) (bool, time.Duration) {
+	period := int64(limit.Period)
+	window := now.UnixNano() / period
+	switch window {
+	case e.window:
+	case e.window + 1:
+		e.prev, e.count = e.count, 0
+	default:
+		e.prev, e.count = 0, 0
+	}
+	e.window = window
+	elapsed := now.UnixNano() - window*period
+	weight := 1 - float64(elapsed)/float64(period)
+	if float64(e.prev)*weight+float64(e.count)+1 <= float64(limit.Rate) {
+		e.count++
+		return true, 0
+	}
+	next := time.Duration(period - elapsed)
+	if e.count+1 > limit.Rate || e.prev == 0 {
+		return false, next
+	}
+	// the weight of the previous window must drop to (rate-count-1)/prev
+	target := float64(limit.Rate-e.count-1) / float64(e.prev)
+	wait := time.Duration(math.Ceil((1-target)*float64(period))) - time.Duration(elapsed)
+	if wait <= 0 || wait > next {
+		wait = next
+	}
+	return false, wait
+}
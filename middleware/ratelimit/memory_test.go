@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	s := NewMemoryStore()
+	limit := Limit{Rate: 2, Period: time.Second, Burst: 3}
+	now := time.Unix(1000, 0)
+	for i := 0; i < 3; i++ {
+		if ok, _, _ := s.Allow("k", limit, now); !ok {
+			t.Fatalf("request %d rejected", i)
+		}
+	}
+	ok, wait, _ := s.Allow("k", limit, now)
+	if ok || wait != 500*time.Millisecond {
+		t.Fatalf("ok: %v, wait: %v", ok, wait)
+	}
+	if ok, _, _ := s.Allow("k", limit, now.Add(500*time.Millisecond)); !ok {
+		t.Fatal("refilled token rejected")
+	}
+	if ok, _, _ := s.Allow("other", limit, now); !ok {
+		t.Fatal("keys are not separated")
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	s := NewMemoryStore()
+	limit := Limit{Algorithm: SlidingWindow, Rate: 4, Period: time.Second}
+	now := time.Unix(1000, 0)
+	for i := 0; i < 4; i++ {
+		if ok, _, _ := s.Allow("k", limit, now.Add(time.Duration(i)*100*time.Millisecond)); !ok {
+			t.Fatalf("request %d rejected", i)
+		}
+	}
+	ok, wait, _ := s.Allow("k", limit, now.Add(500*time.Millisecond))
+	if ok || wait != 500*time.Millisecond {
+		t.Fatalf("ok: %v, wait: %v", ok, wait)
+	}
+	// 4*0.75 of the previous window
+	now = now.Add(1250 * time.Millisecond)
+	if ok, _, _ := s.Allow("k", limit, now); !ok {
+		t.Fatal("request of next window rejected")
+	}
+	ok, wait, _ = s.Allow("k", limit, now)
+	if ok || wait != 250*time.Millisecond {
+		t.Fatalf("ok: %v, wait: %v", ok, wait)
+	}
+}
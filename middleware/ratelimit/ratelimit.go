@@ -0,0 +1,266 @@
+// Package ratelimit limits requests of each key by token bucket or sliding
+// window, rejected requests fail with ResourceExhausted (429) and Retry-After.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/zhiduoke/gapi"
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Algorithm int
+
+const (
+	TokenBucket Algorithm = iota
+	SlidingWindow
+)
+
+// Limit is the quota of a key.
+type Limit struct {
+	Algorithm Algorithm
+	// Rate requests are allowed per Period.
+	Rate   int
+	Period time.Duration
+	// Burst is the capacity of the token bucket, Rate by default.
+	Burst int
+}
+
+// Store keeps the state of keys, it may be shared by gateway instances.
+type Store interface {
+	// Allow takes a request of key, it returns the time to wait if the
+	// request is rejected.
+	Allow(key string, limit Limit, now time.Time) (bool, time.Duration, error)
+}
+
+type Config struct {
+	Limit
+	// Key is the source of the key: "ip" (default), "header:<name>",
+	// "query:<name>", "context:<name>" or "route". The client IP is used if
+	// the value is missing. Keys are separated per route.
+	Key string
+	// TrustForwarded takes the client IP from X-Forwarded-For.
+	TrustForwarded bool
+	// Store is a new MemoryStore by default.
+	Store Store
+}
+
+type keyFunc func(ctx *gapi.Context) string
+
+type Limiter struct {
+	cfg Config
+	key keyFunc
+}
+
+func New(cfg Config) (*Limiter, error) {
+	if cfg.Rate <= 0 {
+		return nil, fmt.Errorf("invalid rate: %d", cfg.Rate)
+	}
+	if cfg.Period <= 0 {
+		cfg.Period = time.Second
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = cfg.Rate
+	}
+	if cfg.Key == "" {
+		cfg.Key = "ip"
+	}
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStore()
+	}
+	l := &Limiter{cfg: cfg}
+	source, name := cfg.Key, ""
+	if i := strings.IndexByte(source, ':'); i >= 0 {
+		source, name = source[:i], source[i+1:]
+	}
+	switch source {
+	case "ip":
+		l.key = l.clientIP
+	case "route":
+		l.key = func(ctx *gapi.Context) string {
+			return "*"
+		}
+	case "header":
+		l.key = func(ctx *gapi.Context) string {
+			return ctx.Request().Header.Get(name)
+		}
+	case "query":
+		l.key = func(ctx *gapi.Context) string {
+			return ctx.Request().URL.Query().Get(name)
+		}
+	case "context":
+		l.key = func(ctx *gapi.Context) string {
+			v, _ := ctx.Get(name)
+			return v
+		}
+	default:
+		return nil, fmt.Errorf("invalid key: %s", cfg.Key)
+	}
+	if source != "ip" && source != "route" && name == "" {
+		return nil, fmt.Errorf("invalid key: %s", cfg.Key)
+	}
+	return l, nil
+}
+
+func (l *Limiter) clientIP(ctx *gapi.Context) string {
+	req := ctx.Request()
+	if l.cfg.TrustForwarded {
+		if xff := req.Header.Get("X-Forwarded-For"); xff != "" {
+			if i := strings.IndexByte(xff, ','); i >= 0 {
+				xff = xff[:i]
+			}
+			return strings.TrimSpace(xff)
+		}
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// Handle is the middleware.
+func (l *Limiter) Handle(ctx *gapi.Context) error {
+	var key string
+	if value := l.key(ctx); value != "" {
+		key = l.cfg.Key + "=" + value
+	} else {
+		key = "ip=" + l.clientIP(ctx)
+	}
+	if route := ctx.Route(); route != nil {
+		// bindings of a call have quotas of their own
+		key = route.Method + " " + route.Path + "|" + key
+	}
+	ok, wait, err := l.cfg.Store.Allow(key, l.cfg.Limit, time.Now())
+	if err != nil {
+		// don't block requests because of the store
+		logrus.Warnf("rate limit %s: %v", key, err)
+		return ctx.Next()
+	}
+	if !ok {
+		seconds := int64(math.Ceil(wait.Seconds()))
+		if seconds < 1 {
+			seconds = 1
+		}
+		setRetryAfter(ctx.Response().Header(), seconds)
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return ctx.Next()
+}
+
+func setRetryAfter(h http.Header, seconds int64) {
+	h.Set("Retry-After", strconv.FormatInt(seconds, 10))
+}
+
+// Factory builds limiters from arguments of use, cfg provides the defaults
+// and a Store shared by all routes:
+//
+//	ratelimit(100/s, burst=200, key=context:uid, algorithm=sliding_window)
+//
+// The rate is "<n>/<period>" where period is s, m, h or a duration like 10s.
+func Factory(cfg Config) gapi.MiddlewareFactory {
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStore()
+	}
+	return func(m *metadata.Middleware) (gapi.HandleFunc, error) {
+		c, err := parseUse(cfg, m)
+		if err != nil {
+			return nil, err
+		}
+		l, err := New(c)
+		if err != nil {
+			return nil, err
+		}
+		return l.Handle, nil
+	}
+}
+
+// Validator checks arguments of use like Factory without building limiters.
+func Validator(cfg Config) gapi.MiddlewareValidator {
+	return func(m *metadata.Middleware) error {
+		c, err := parseUse(cfg, m)
+		if err != nil {
+			return err
+		}
+		_, err = New(c)
+		return err
+	}
+}
+
+// parseUse overrides cfg with arguments of use.
+func parseUse(c Config, m *metadata.Middleware) (Config, error) {
+	rate, hasRate := m.Get("rate")
+	switch len(m.Args) {
+	case 0:
+	case 1:
+		if hasRate {
+			return c, fmt.Errorf("duplicated rate")
+		}
+		rate, hasRate = m.Args[0], true
+	default:
+		return c, fmt.Errorf("too many arguments")
+	}
+	if hasRate {
+		var err error
+		c.Rate, c.Period, err = parseRate(rate)
+		if err != nil {
+			return c, err
+		}
+		// the burst of defaults doesn't apply to another rate
+		c.Burst = 0
+	}
+	for name, value := range m.Options {
+		switch name {
+		case "rate":
+		case "burst":
+			burst, err := strconv.Atoi(value)
+			if err != nil || burst <= 0 {
+				return c, fmt.Errorf("invalid burst: %s", value)
+			}
+			c.Burst = burst
+		case "key":
+			c.Key = value
+		case "algorithm":
+			switch value {
+			case "token_bucket":
+				c.Algorithm = TokenBucket
+			case "sliding_window":
+				c.Algorithm = SlidingWindow
+			default:
+				return c, fmt.Errorf("invalid algorithm: %s", value)
+			}
+		default:
+			return c, fmt.Errorf("unknown argument: %s", name)
+		}
+	}
+	return c, nil
+}
+
+func parseRate(s string) (int, time.Duration, error) {
+	n, unit := s, "s"
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		n, unit = s[:i], s[i+1:]
+	}
+	rate, err := strconv.Atoi(n)
+	if err != nil || rate <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate: %s", s)
+	}
+	switch unit {
+	case "s", "m", "h":
+		unit = "1" + unit
+	}
+	period, err := time.ParseDuration(unit)
+	if err != nil || period <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate: %s", s)
+	}
+	return rate, period, nil
+}
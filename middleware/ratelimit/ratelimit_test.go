@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zhiduoke/gapi"
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		s      string
+		rate   int
+		period time.Duration
+	}{
+		{"10", 10, time.Second},
+		{"100/s", 100, time.Second},
+		{"5/m", 5, time.Minute},
+		{"3/10s", 3, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		rate, period, err := parseRate(tt.s)
+		if err != nil || rate != tt.rate || period != tt.period {
+			t.Errorf("%s: %d %v %v", tt.s, rate, period, err)
+		}
+	}
+	for _, s := range []string{"", "0/s", "x/s", "1/x", "1/-1s"} {
+		if _, _, err := parseRate(s); err == nil {
+			t.Errorf("%s: no error", s)
+		}
+	}
+}
+
+func TestFactory(t *testing.T) {
+	f := Factory(Config{Limit: Limit{Rate: 1}})
+	v := Validator(Config{Limit: Limit{Rate: 1}})
+	valid := []string{
+		"ratelimit",
+		"ratelimit(100/s, burst=200, key=context:uid, algorithm=sliding_window)",
+		`ratelimit(rate="10/m", key="header:X-Api-Key")`,
+	}
+	for _, s := range valid {
+		m, err := metadata.ParseMiddleware(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f(m); err != nil {
+			t.Errorf("%s: %v", s, err)
+		}
+		if err := v(m); err != nil {
+			t.Errorf("validate %s: %v", s, err)
+		}
+	}
+	invalid := []string{
+		"ratelimit(1/s, 2/s)",
+		"ratelimit(1/s, rate=2/s)",
+		"ratelimit(burst=0)",
+		"ratelimit(key=cookie:x)",
+		"ratelimit(algorithm=leaky)",
+		"ratelimit(unknown=1)",
+	}
+	for _, s := range invalid {
+		m, err := metadata.ParseMiddleware(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f(m); err == nil {
+			t.Errorf("%s: no error", s)
+		}
+		if err := v(m); err == nil {
+			t.Errorf("validate %s: no error", s)
+		}
+	}
+}
+
+type keyStore []string
+
+func (s *keyStore) Allow(key string, limit Limit, now time.Time) (bool, time.Duration, error) {
+	*s = append(*s, key)
+	return false, time.Second, nil
+}
+
+type nopHandler struct{}
+
+func (nopHandler) HandleRequest(call *metadata.Call, ctx *gapi.Context) ([]byte, error) {
+	return nil, nil
+}
+
+func (nopHandler) WriteResponse(call *metadata.Call, ctx *gapi.Context, data []byte) error {
+	return nil
+}
+
+func TestRouteKey(t *testing.T) {
+	var keys keyStore
+	s := gapi.NewServer()
+	s.RegisterHandler("nop", nopHandler{})
+	s.RegisterMiddlewareFactory("ratelimit", Factory(Config{Limit: Limit{Rate: 1}, Key: "route", Store: &keys}))
+	m, err := metadata.ParseMiddleware("ratelimit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// an additional binding shares the call
+	call := &metadata.Call{
+		Server:  "127.0.0.1:1",
+		Handler: "nop",
+		Name:    "/a.A/A",
+		In:      &metadata.Message{Name: "a.Req"},
+		Out:     &metadata.Message{Name: "a.Res"},
+	}
+	opts := metadata.RouteOptions{Middlewares: []*metadata.Middleware{m}}
+	md := &metadata.Metadata{Routes: []*metadata.Route{
+		{Method: http.MethodPost, Path: "/a", Options: opts, Call: call},
+		{Method: http.MethodGet, Path: "/a", Options: opts, Call: call},
+	}}
+	if err := s.UpdateRoute(md); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Shutdown(context.Background())
+	for _, method := range []string{http.MethodPost, http.MethodGet} {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(method, "/a", nil))
+		if w.Code != http.StatusTooManyRequests {
+			t.Fatalf("%s: status %d", method, w.Code)
+		}
+	}
+	if len(keys) != 2 || keys[0] != "POST /a|route=*" || keys[1] != "GET /a|route=*" {
+		t.Fatalf("keys: %q", keys)
+	}
+}
@@ -26,6 +26,58 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type JSON_NAMING int32
+
+const (
+	// field names in .proto files
+	JSON_NAMING_ORIGINAL_NAME JSON_NAMING = 0
+	// json_name of fields, which is lowerCamelCase by default
+	JSON_NAMING_JSON_NAME JSON_NAMING = 1
+	// lowerCamelCase of field names even if json_name is set
+	JSON_NAMING_CAMEL_CASE JSON_NAMING = 2
+)
+
+// Enum value maps for JSON_NAMING.
+var (
+	JSON_NAMING_name = map[int32]string{
+		0: "ORIGINAL_NAME",
+		1: "JSON_NAME",
+		2: "CAMEL_CASE",
+	}
+	JSON_NAMING_value = map[string]int32{
+		"ORIGINAL_NAME": 0,
+		"JSON_NAME":     1,
+		"CAMEL_CASE":    2,
+	}
+)
+
+func (x JSON_NAMING) Enum() *JSON_NAMING {
+	p := new(JSON_NAMING)
+	*p = x
+	return p
+}
+
+func (x JSON_NAMING) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JSON_NAMING) Descriptor() protoreflect.EnumDescriptor {
+	return file_annotation_proto_enumTypes[0].Descriptor()
+}
+
+func (JSON_NAMING) Type() protoreflect.EnumType {
+	return &file_annotation_proto_enumTypes[0]
+}
+
+func (x JSON_NAMING) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JSON_NAMING.Descriptor instead.
+func (JSON_NAMING) EnumDescriptor() ([]byte, []int) {
+	return file_annotation_proto_rawDescGZIP(), []int{0}
+}
+
 type FIELD_BIND int32
 
 const (
@@ -65,11 +117,11 @@ func (x FIELD_BIND) String() string {
 }
 
 func (FIELD_BIND) Descriptor() protoreflect.EnumDescriptor {
-	return file_annotation_proto_enumTypes[0].Descriptor()
+	return file_annotation_proto_enumTypes[1].Descriptor()
 }
 
 func (FIELD_BIND) Type() protoreflect.EnumType {
-	return &file_annotation_proto_enumTypes[0]
+	return &file_annotation_proto_enumTypes[1]
 }
 
 func (x FIELD_BIND) Number() protoreflect.EnumNumber {
@@ -78,7 +130,7 @@ func (x FIELD_BIND) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use FIELD_BIND.Descriptor instead.
 func (FIELD_BIND) EnumDescriptor() ([]byte, []int) {
-	return file_annotation_proto_rawDescGZIP(), []int{0}
+	return file_annotation_proto_rawDescGZIP(), []int{1}
 }
 
 type Http struct {
@@ -94,9 +146,27 @@ type Http struct {
 	//	*Http_Patch
 	//	*Http_Option
 	Pattern isHttp_Pattern `protobuf_oneof:"pattern"`
-	Use     []string       `protobuf:"bytes,7,rep,name=use,proto3" json:"use,omitempty"`
-	Timeout int32          `protobuf:"varint,8,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Handler string         `protobuf:"bytes,9,opt,name=handler,proto3" json:"handler,omitempty"`
+	// middlewares with optional arguments: "auth" or
+	// "ratelimit(100/s, key=context:uid)"
+	Use     []string `protobuf:"bytes,7,rep,name=use,proto3" json:"use,omitempty"`
+	Timeout int32    `protobuf:"varint,8,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Handler string   `protobuf:"bytes,9,opt,name=handler,proto3" json:"handler,omitempty"`
+	Upload  *Upload  `protobuf:"bytes,10,opt,name=upload,proto3" json:"upload,omitempty"`
+	// request headers forwarded as metadata besides the server policy:
+	// "X-Request-Id", "X-B3-*" (prefix) or "Authorization=auth-token" (rename)
+	ForwardHeaders []string `protobuf:"bytes,11,rep,name=forward_headers,json=forwardHeaders,proto3" json:"forward_headers,omitempty"`
+	// context values forwarded as metadata besides Server.ForwardContext:
+	// "uid" or "uid=x-user-id" (rename)
+	ForwardContext []string `protobuf:"bytes,12,rep,name=forward_context,json=forwardContext,proto3" json:"forward_context,omitempty"`
+	// only applied to methods with idempotency_level IDEMPOTENT or
+	// NO_SIDE_EFFECTS, overrides default_retry of the service
+	Retry *Retry `protobuf:"bytes,13,opt,name=retry,proto3" json:"retry,omitempty"`
+	// more routes of the method, e.g. a legacy path, with their own pattern,
+	// use, handler, forward_headers and forward_context, which are inherited
+	// if not set. Other options can't be set and bindings can't be nested.
+	AdditionalBindings []*Http `protobuf:"bytes,14,rep,name=additional_bindings,json=additionalBindings,proto3" json:"additional_bindings,omitempty"`
+	// limits of requests, which override limits of the handler
+	Limits *Limits `protobuf:"bytes,15,opt,name=limits,proto3" json:"limits,omitempty"`
 }
 
 func (x *Http) Reset() {
@@ -201,6 +271,48 @@ func (x *Http) GetHandler() string {
 	return ""
 }
 
+func (x *Http) GetUpload() *Upload {
+	if x != nil {
+		return x.Upload
+	}
+	return nil
+}
+
+func (x *Http) GetForwardHeaders() []string {
+	if x != nil {
+		return x.ForwardHeaders
+	}
+	return nil
+}
+
+func (x *Http) GetForwardContext() []string {
+	if x != nil {
+		return x.ForwardContext
+	}
+	return nil
+}
+
+func (x *Http) GetRetry() *Retry {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *Http) GetAdditionalBindings() []*Http {
+	if x != nil {
+		return x.AdditionalBindings
+	}
+	return nil
+}
+
+func (x *Http) GetLimits() *Limits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
 type isHttp_Pattern interface {
 	isHttp_Pattern()
 }
@@ -241,6 +353,255 @@ func (*Http_Patch) isHttp_Pattern() {}
 
 func (*Http_Option) isHttp_Pattern() {}
 
+// Limits bound the size of JSON requests, zero means the limit of the handler.
+type Limits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// max bytes of the request body, or of each websocket message
+	MaxBodyBytes int64 `protobuf:"varint,1,opt,name=max_body_bytes,json=maxBodyBytes,proto3" json:"max_body_bytes,omitempty"`
+	// max nesting depth of objects and arrays
+	MaxDepth int32 `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	// max length of each array
+	MaxArrayLength int32 `protobuf:"varint,3,opt,name=max_array_length,json=maxArrayLength,proto3" json:"max_array_length,omitempty"`
+	// max entries of each map or google.protobuf.Struct
+	MaxMapEntries int32 `protobuf:"varint,4,opt,name=max_map_entries,json=maxMapEntries,proto3" json:"max_map_entries,omitempty"`
+	// max bytes of each string
+	MaxStringLength int32 `protobuf:"varint,5,opt,name=max_string_length,json=maxStringLength,proto3" json:"max_string_length,omitempty"`
+}
+
+func (x *Limits) Reset() {
+	*x = Limits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_annotation_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Limits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limits) ProtoMessage() {}
+
+func (x *Limits) ProtoReflect() protoreflect.Message {
+	mi := &file_annotation_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limits.ProtoReflect.Descriptor instead.
+func (*Limits) Descriptor() ([]byte, []int) {
+	return file_annotation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Limits) GetMaxBodyBytes() int64 {
+	if x != nil {
+		return x.MaxBodyBytes
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxArrayLength() int32 {
+	if x != nil {
+		return x.MaxArrayLength
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxMapEntries() int32 {
+	if x != nil {
+		return x.MaxMapEntries
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxStringLength() int32 {
+	if x != nil {
+		return x.MaxStringLength
+	}
+	return 0
+}
+
+// Retry retries unary calls which failed with retryable codes.
+type Retry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// attempts including the first one, 3 by default
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// backoff in milliseconds, 100ms and 1s by default
+	InitialBackoff int32 `protobuf:"varint,2,opt,name=initial_backoff,json=initialBackoff,proto3" json:"initial_backoff,omitempty"`
+	MaxBackoff     int32 `protobuf:"varint,3,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`
+	// 2 by default
+	BackoffMultiplier float64 `protobuf:"fixed64,4,opt,name=backoff_multiplier,json=backoffMultiplier,proto3" json:"backoff_multiplier,omitempty"`
+	// code names, e.g. "UNAVAILABLE" (default)
+	RetryableCodes []string `protobuf:"bytes,5,rep,name=retryable_codes,json=retryableCodes,proto3" json:"retryable_codes,omitempty"`
+	// send another attempt if no response in hedging_delay milliseconds,
+	// backoff is not used by hedging
+	HedgingDelay int32 `protobuf:"varint,6,opt,name=hedging_delay,json=hedgingDelay,proto3" json:"hedging_delay,omitempty"`
+}
+
+func (x *Retry) Reset() {
+	*x = Retry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_annotation_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Retry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Retry) ProtoMessage() {}
+
+func (x *Retry) ProtoReflect() protoreflect.Message {
+	mi := &file_annotation_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Retry.ProtoReflect.Descriptor instead.
+func (*Retry) Descriptor() ([]byte, []int) {
+	return file_annotation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Retry) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Retry) GetInitialBackoff() int32 {
+	if x != nil {
+		return x.InitialBackoff
+	}
+	return 0
+}
+
+func (x *Retry) GetMaxBackoff() int32 {
+	if x != nil {
+		return x.MaxBackoff
+	}
+	return 0
+}
+
+func (x *Retry) GetBackoffMultiplier() float64 {
+	if x != nil {
+		return x.BackoffMultiplier
+	}
+	return 0
+}
+
+func (x *Retry) GetRetryableCodes() []string {
+	if x != nil {
+		return x.RetryableCodes
+	}
+	return nil
+}
+
+func (x *Retry) GetHedgingDelay() int32 {
+	if x != nil {
+		return x.HedgingDelay
+	}
+	return 0
+}
+
+// Upload streams the request body to a client-streaming method, the body is
+// split into messages which carry a chunk of it in a bytes field. Values bound
+// from the request are only set in the first message.
+type Upload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of the bytes field in the input message
+	ChunkField string `protobuf:"bytes,1,opt,name=chunk_field,json=chunkField,proto3" json:"chunk_field,omitempty"`
+	// max size of each chunk, 64KiB by default
+	ChunkSize int32 `protobuf:"varint,2,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// form field of the file in multipart/form-data requests, the first file
+	// is used by default. Other request bodies are streamed as is.
+	FormFile string `protobuf:"bytes,3,opt,name=form_file,json=formFile,proto3" json:"form_file,omitempty"`
+}
+
+func (x *Upload) Reset() {
+	*x = Upload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_annotation_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upload) ProtoMessage() {}
+
+func (x *Upload) ProtoReflect() protoreflect.Message {
+	mi := &file_annotation_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upload.ProtoReflect.Descriptor instead.
+func (*Upload) Descriptor() ([]byte, []int) {
+	return file_annotation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Upload) GetChunkField() string {
+	if x != nil {
+		return x.ChunkField
+	}
+	return ""
+}
+
+func (x *Upload) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *Upload) GetFormFile() string {
+	if x != nil {
+		return x.FormFile
+	}
+	return ""
+}
+
 var file_annotation_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptor.MethodOptions)(nil),
@@ -282,6 +643,30 @@ var file_annotation_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,4110206,opt,name=path_prefix",
 		Filename:      "annotation.proto",
 	},
+	{
+		ExtendedType:  (*descriptor.ServiceOptions)(nil),
+		ExtensionType: (*Retry)(nil),
+		Field:         4110207,
+		Name:          "gapi.default_retry",
+		Tag:           "bytes,4110207,opt,name=default_retry",
+		Filename:      "annotation.proto",
+	},
+	{
+		ExtendedType:  (*descriptor.ServiceOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         4110208,
+		Name:          "gapi.balancer",
+		Tag:           "bytes,4110208,opt,name=balancer",
+		Filename:      "annotation.proto",
+	},
+	{
+		ExtendedType:  (*descriptor.ServiceOptions)(nil),
+		ExtensionType: (*JSON_NAMING)(nil),
+		Field:         4110209,
+		Name:          "gapi.json_naming",
+		Tag:           "varint,4110209,opt,name=json_naming,enum=gapi.JSON_NAMING",
+		Filename:      "annotation.proto",
+	},
 	{
 		ExtendedType:  (*descriptor.MessageOptions)(nil),
 		ExtensionType: (*bool)(nil),
@@ -290,6 +675,22 @@ var file_annotation_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "varint,5110202,opt,name=flat",
 		Filename:      "annotation.proto",
 	},
+	{
+		ExtendedType:  (*descriptor.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         5110203,
+		Name:          "gapi.enums_as_string",
+		Tag:           "varint,5110203,opt,name=enums_as_string",
+		Filename:      "annotation.proto",
+	},
+	{
+		ExtendedType:  (*descriptor.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         5110204,
+		Name:          "gapi.int64s_as_string",
+		Tag:           "varint,5110204,opt,name=int64s_as_string",
+		Filename:      "annotation.proto",
+	},
 	{
 		ExtendedType:  (*descriptor.FieldOptions)(nil),
 		ExtensionType: (*string)(nil),
@@ -338,6 +739,22 @@ var file_annotation_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "varint,6110209,opt,name=bind,enum=gapi.FIELD_BIND",
 		Filename:      "annotation.proto",
 	},
+	{
+		ExtendedType:  (*descriptor.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         6110210,
+		Name:          "gapi.enum_as_string",
+		Tag:           "varint,6110210,opt,name=enum_as_string",
+		Filename:      "annotation.proto",
+	},
+	{
+		ExtendedType:  (*descriptor.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         6110211,
+		Name:          "gapi.int64_as_string",
+		Tag:           "varint,6110211,opt,name=int64_as_string",
+		Filename:      "annotation.proto",
+	},
 }
 
 // Extension fields to descriptor.MethodOptions.
@@ -348,6 +765,10 @@ var (
 
 // Extension fields to descriptor.ServiceOptions.
 var (
+	// target of the upstream server, e.g. "127.0.0.1:9090",
+	// "dns:///users:9090", "static:///10.0.0.1:9090,10.0.0.2:9090",
+	// "srv:///_grpc._tcp.users" or "file:///etc/gapi/users.endpoints"
+	//
 	// optional string server = 4110202;
 	E_Server = &file_annotation_proto_extTypes[1]
 	// optional string default_handler = 4110204;
@@ -356,28 +777,52 @@ var (
 	E_DefaultTimeout = &file_annotation_proto_extTypes[3]
 	// optional string path_prefix = 4110206;
 	E_PathPrefix = &file_annotation_proto_extTypes[4]
+	// optional gapi.Retry default_retry = 4110207;
+	E_DefaultRetry = &file_annotation_proto_extTypes[5]
+	// balancer of the server: pick_first (default), round_robin or
+	// least_request
+	//
+	// optional string balancer = 4110208;
+	E_Balancer = &file_annotation_proto_extTypes[6]
+	// keys of JSON responses, keys of any style are accepted in requests
+	//
+	// optional gapi.JSON_NAMING json_naming = 4110209;
+	E_JsonNaming = &file_annotation_proto_extTypes[7]
 )
 
 // Extension fields to descriptor.MessageOptions.
 var (
 	// optional bool flat = 5110202;
-	E_Flat = &file_annotation_proto_extTypes[5]
+	E_Flat = &file_annotation_proto_extTypes[8]
+	// optional bool enums_as_string = 5110203;
+	E_EnumsAsString = &file_annotation_proto_extTypes[9]
+	// default of int64_as_string of fields
+	//
+	// optional bool int64s_as_string = 5110204;
+	E_Int64SAsString = &file_annotation_proto_extTypes[10]
 )
 
 // Extension fields to descriptor.FieldOptions.
 var (
 	// optional string alias = 6110202;
-	E_Alias = &file_annotation_proto_extTypes[6]
+	E_Alias = &file_annotation_proto_extTypes[11]
 	// optional bool omit_empty = 6110203;
-	E_OmitEmpty = &file_annotation_proto_extTypes[7]
+	E_OmitEmpty = &file_annotation_proto_extTypes[12]
 	// optional bool raw_data = 6110204;
-	E_RawData = &file_annotation_proto_extTypes[8]
+	E_RawData = &file_annotation_proto_extTypes[13]
 	// optional bool from_context = 6110206;
-	E_FromContext = &file_annotation_proto_extTypes[9]
+	E_FromContext = &file_annotation_proto_extTypes[14]
 	// optional bool validate = 6110207;
-	E_Validate = &file_annotation_proto_extTypes[10]
+	E_Validate = &file_annotation_proto_extTypes[15]
 	// optional gapi.FIELD_BIND bind = 6110209;
-	E_Bind = &file_annotation_proto_extTypes[11]
+	E_Bind = &file_annotation_proto_extTypes[16]
+	// optional bool enum_as_string = 6110210;
+	E_EnumAsString = &file_annotation_proto_extTypes[17]
+	// write 64-bit integers as strings, which can't be represented by
+	// numbers of JavaScript above 2^53
+	//
+	// optional bool int64_as_string = 6110211;
+	E_Int64AsString = &file_annotation_proto_extTypes[18]
 )
 
 var File_annotation_proto protoreflect.FileDescriptor
@@ -386,7 +831,7 @@ var file_annotation_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x04, 0x67, 0x61, 0x70, 0x69, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe1, 0x01, 0x0a, 0x04, 0x48,
+	0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdf, 0x03, 0x0a, 0x04, 0x48,
 	0x74, 0x74, 0x70, 0x12, 0x14, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x48, 0x00, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x67, 0x65, 0x74,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x67, 0x65, 0x74, 0x12, 0x18, 0x0a,
@@ -400,63 +845,151 @@ var file_annotation_proto_rawDesc = []byte{
 	0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05,
 	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x6e,
 	0x64, 0x6c, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x61, 0x6e, 0x64,
-	0x6c, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x2a, 0x62,
-	0x0a, 0x0a, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x42, 0x49, 0x4e, 0x44, 0x12, 0x10, 0x0a, 0x0c,
-	0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x10,
-	0x0a, 0x0c, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x45, 0x58, 0x54, 0x10, 0x01,
-	0x12, 0x0e, 0x0a, 0x0a, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x10, 0x02,
-	0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x48, 0x45, 0x41, 0x44, 0x45, 0x52, 0x10,
-	0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x50, 0x41, 0x52, 0x41, 0x4d, 0x53,
-	0x10, 0x04, 0x3a, 0x41, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xba, 0xea, 0xbd, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x52,
-	0x04, 0x68, 0x74, 0x74, 0x70, 0x3a, 0x3a, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
-	0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0xfa, 0xee, 0xfa, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x3a, 0x4b, 0x0a, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x68, 0x61, 0x6e,
-	0x64, 0x6c, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfc, 0xee, 0xfa, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
-	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x3a, 0x4b,
-	0x0a, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
-	0x74, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0xfd, 0xee, 0xfa, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x64, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x3a, 0x43, 0x0a, 0x0b, 0x70,
-	0x61, 0x74, 0x68, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f,
+	0x6c, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x06, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x06, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x72,
+	0x65, 0x74, 0x72, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x61, 0x70,
+	0x69, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x3b,
+	0x0a, 0x13, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x62, 0x69, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x61,
+	0x70, 0x69, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x52, 0x12, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61,
+	0x70, 0x69, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0xc9, 0x01, 0x0a,
+	0x06, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x62,
+	0x6f, 0x64, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x6d, 0x61, 0x78, 0x42, 0x6f, 0x64, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61,
+	0x78, 0x5f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x41, 0x72, 0x72, 0x61, 0x79, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x61, 0x70, 0x5f,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d,
+	0x61, 0x78, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11,
+	0x6d, 0x61, 0x78, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0xf1, 0x01, 0x0a, 0x05, 0x52, 0x65, 0x74,
+	0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x41, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
+	0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12,
+	0x2d, 0x0a, 0x12, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x62, 0x61, 0x63,
+	0x6b, 0x6f, 0x66, 0x66, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x12, 0x27,
+	0x0a, 0x0f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62,
+	0x6c, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x65, 0x64, 0x67, 0x69,
+	0x6e, 0x67, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x68, 0x65, 0x64, 0x67, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x65, 0x0a, 0x06,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x6f, 0x72, 0x6d, 0x46,
+	0x69, 0x6c, 0x65, 0x2a, 0x3f, 0x0a, 0x0b, 0x4a, 0x53, 0x4f, 0x4e, 0x5f, 0x4e, 0x41, 0x4d, 0x49,
+	0x4e, 0x47, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x52, 0x49, 0x47, 0x49, 0x4e, 0x41, 0x4c, 0x5f, 0x4e,
+	0x41, 0x4d, 0x45, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4a, 0x53, 0x4f, 0x4e, 0x5f, 0x4e, 0x41,
+	0x4d, 0x45, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x41, 0x4d, 0x45, 0x4c, 0x5f, 0x43, 0x41,
+	0x53, 0x45, 0x10, 0x02, 0x2a, 0x62, 0x0a, 0x0a, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x42, 0x49,
+	0x4e, 0x44, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55,
+	0x4c, 0x54, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x43, 0x4f, 0x4e,
+	0x54, 0x45, 0x58, 0x54, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x51,
+	0x55, 0x45, 0x52, 0x59, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x48,
+	0x45, 0x41, 0x44, 0x45, 0x52, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x52, 0x4f, 0x4d, 0x5f,
+	0x50, 0x41, 0x52, 0x41, 0x4d, 0x53, 0x10, 0x04, 0x3a, 0x41, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70,
+	0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0xba, 0xea, 0xbd, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x61, 0x70, 0x69,
+	0x2e, 0x48, 0x74, 0x74, 0x70, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x3a, 0x3a, 0x0a, 0x06, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfa, 0xee, 0xfa, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x3a, 0x4b, 0x0a, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfe, 0xee, 0xfa, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x74, 0x68, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
-	0x3a, 0x36, 0x0a, 0x04, 0x66, 0x6c, 0x61, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xba, 0xf3, 0xb7, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x04, 0x66, 0x6c, 0x61, 0x74, 0x3a, 0x36, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61,
-	0x73, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0xfa, 0xf7, 0xf4, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73,
-	0x3a, 0x3f, 0x0a, 0x0a, 0x6f, 0x6d, 0x69, 0x74, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x1d,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfb, 0xf7,
-	0xf4, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6f, 0x6d, 0x69, 0x74, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x3a, 0x3b, 0x0a, 0x08, 0x72, 0x61, 0x77, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfc, 0xf7, 0xf4,
-	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x61, 0x77, 0x44, 0x61, 0x74, 0x61, 0x3a, 0x43,
-	0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1d,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfe, 0xf7,
-	0xf4, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x78, 0x74, 0x3a, 0x3c, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfc, 0xee, 0xfa, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x48, 0x61, 0x6e,
+	0x64, 0x6c, 0x65, 0x72, 0x3a, 0x4b, 0x0a, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfd, 0xee, 0xfa, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x3a, 0x43, 0x0a, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0xfe, 0xee, 0xfa, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x74, 0x68,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x3a, 0x54, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xff, 0xee, 0xfa, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x0c,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x74, 0x72, 0x79, 0x3a, 0x3e, 0x0a, 0x08,
+	0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x80, 0xef, 0xfa, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x3a, 0x56, 0x0a, 0x0b,
+	0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x81, 0xef, 0xfa,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x70, 0x69, 0x2e, 0x4a, 0x53, 0x4f,
+	0x4e, 0x5f, 0x4e, 0x41, 0x4d, 0x49, 0x4e, 0x47, 0x52, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x4e, 0x61,
+	0x6d, 0x69, 0x6e, 0x67, 0x3a, 0x36, 0x0a, 0x04, 0x66, 0x6c, 0x61, 0x74, 0x12, 0x1f, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xba, 0xf3,
+	0xb7, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x6c, 0x61, 0x74, 0x3a, 0x4a, 0x0a, 0x0f,
+	0x65, 0x6e, 0x75, 0x6d, 0x73, 0x5f, 0x61, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12,
+	0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0xbb, 0xf3, 0xb7, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65, 0x6e, 0x75, 0x6d, 0x73,
+	0x41, 0x73, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x3a, 0x4c, 0x0a, 0x10, 0x69, 0x6e, 0x74, 0x36,
+	0x34, 0x73, 0x5f, 0x61, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xbc, 0xf3,
+	0xb7, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x73, 0x41, 0x73,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x3a, 0x36, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x12,
 	0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xff,
-	0xf7, 0xf4, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
-	0x65, 0x3a, 0x46, 0x0a, 0x04, 0x62, 0x69, 0x6e, 0x64, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfa,
+	0xf7, 0xf4, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x3a, 0x3f,
+	0x0a, 0x0a, 0x6f, 0x6d, 0x69, 0x74, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x1d, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfb, 0xf7, 0xf4, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6f, 0x6d, 0x69, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x3a,
+	0x3b, 0x0a, 0x08, 0x72, 0x61, 0x77, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfc, 0xf7, 0xf4, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x61, 0x77, 0x44, 0x61, 0x74, 0x61, 0x3a, 0x43, 0x0a, 0x0c,
+	0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1d, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xfe, 0xf7, 0xf4, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x3a, 0x3c, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xff, 0xf7, 0xf4,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x3a,
+	0x46, 0x0a, 0x04, 0x62, 0x69, 0x6e, 0x64, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x81, 0xf8, 0xf4, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x10, 0x2e, 0x67, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x42, 0x49, 0x4e,
+	0x44, 0x52, 0x04, 0x62, 0x69, 0x6e, 0x64, 0x3a, 0x46, 0x0a, 0x0e, 0x65, 0x6e, 0x75, 0x6d, 0x5f,
+	0x61, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x81, 0xf8, 0xf4, 0x02, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x10, 0x2e, 0x67, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x42,
-	0x49, 0x4e, 0x44, 0x52, 0x04, 0x62, 0x69, 0x6e, 0x64, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x69, 0x74,
+	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x82, 0xf8, 0xf4, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x65, 0x6e, 0x75, 0x6d, 0x41, 0x73, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x3a,
+	0x48, 0x0a, 0x0f, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x5f, 0x61, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x83, 0xf8, 0xf4, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x36,
+	0x34, 0x41, 0x73, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x69, 0x74,
 	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x7a, 0x68, 0x69, 0x64, 0x75, 0x6f, 0x6b, 0x65,
 	0x2f, 0x67, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x33,
@@ -474,36 +1007,53 @@ func file_annotation_proto_rawDescGZIP() []byte {
 	return file_annotation_proto_rawDescData
 }
 
-var file_annotation_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_annotation_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_annotation_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_annotation_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_annotation_proto_goTypes = []interface{}{
-	(FIELD_BIND)(0),                   // 0: gapi.FIELD_BIND
-	(*Http)(nil),                      // 1: gapi.Http
-	(*descriptor.MethodOptions)(nil),  // 2: google.protobuf.MethodOptions
-	(*descriptor.ServiceOptions)(nil), // 3: google.protobuf.ServiceOptions
-	(*descriptor.MessageOptions)(nil), // 4: google.protobuf.MessageOptions
-	(*descriptor.FieldOptions)(nil),   // 5: google.protobuf.FieldOptions
+	(JSON_NAMING)(0),                  // 0: gapi.JSON_NAMING
+	(FIELD_BIND)(0),                   // 1: gapi.FIELD_BIND
+	(*Http)(nil),                      // 2: gapi.Http
+	(*Limits)(nil),                    // 3: gapi.Limits
+	(*Retry)(nil),                     // 4: gapi.Retry
+	(*Upload)(nil),                    // 5: gapi.Upload
+	(*descriptor.MethodOptions)(nil),  // 6: google.protobuf.MethodOptions
+	(*descriptor.ServiceOptions)(nil), // 7: google.protobuf.ServiceOptions
+	(*descriptor.MessageOptions)(nil), // 8: google.protobuf.MessageOptions
+	(*descriptor.FieldOptions)(nil),   // 9: google.protobuf.FieldOptions
 }
 var file_annotation_proto_depIdxs = []int32{
-	2,  // 0: gapi.http:extendee -> google.protobuf.MethodOptions
-	3,  // 1: gapi.server:extendee -> google.protobuf.ServiceOptions
-	3,  // 2: gapi.default_handler:extendee -> google.protobuf.ServiceOptions
-	3,  // 3: gapi.default_timeout:extendee -> google.protobuf.ServiceOptions
-	3,  // 4: gapi.path_prefix:extendee -> google.protobuf.ServiceOptions
-	4,  // 5: gapi.flat:extendee -> google.protobuf.MessageOptions
-	5,  // 6: gapi.alias:extendee -> google.protobuf.FieldOptions
-	5,  // 7: gapi.omit_empty:extendee -> google.protobuf.FieldOptions
-	5,  // 8: gapi.raw_data:extendee -> google.protobuf.FieldOptions
-	5,  // 9: gapi.from_context:extendee -> google.protobuf.FieldOptions
-	5,  // 10: gapi.validate:extendee -> google.protobuf.FieldOptions
-	5,  // 11: gapi.bind:extendee -> google.protobuf.FieldOptions
-	1,  // 12: gapi.http:type_name -> gapi.Http
-	0,  // 13: gapi.bind:type_name -> gapi.FIELD_BIND
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	12, // [12:14] is the sub-list for extension type_name
-	0,  // [0:12] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	5,  // 0: gapi.Http.upload:type_name -> gapi.Upload
+	4,  // 1: gapi.Http.retry:type_name -> gapi.Retry
+	2,  // 2: gapi.Http.additional_bindings:type_name -> gapi.Http
+	3,  // 3: gapi.Http.limits:type_name -> gapi.Limits
+	6,  // 4: gapi.http:extendee -> google.protobuf.MethodOptions
+	7,  // 5: gapi.server:extendee -> google.protobuf.ServiceOptions
+	7,  // 6: gapi.default_handler:extendee -> google.protobuf.ServiceOptions
+	7,  // 7: gapi.default_timeout:extendee -> google.protobuf.ServiceOptions
+	7,  // 8: gapi.path_prefix:extendee -> google.protobuf.ServiceOptions
+	7,  // 9: gapi.default_retry:extendee -> google.protobuf.ServiceOptions
+	7,  // 10: gapi.balancer:extendee -> google.protobuf.ServiceOptions
+	7,  // 11: gapi.json_naming:extendee -> google.protobuf.ServiceOptions
+	8,  // 12: gapi.flat:extendee -> google.protobuf.MessageOptions
+	8,  // 13: gapi.enums_as_string:extendee -> google.protobuf.MessageOptions
+	8,  // 14: gapi.int64s_as_string:extendee -> google.protobuf.MessageOptions
+	9,  // 15: gapi.alias:extendee -> google.protobuf.FieldOptions
+	9,  // 16: gapi.omit_empty:extendee -> google.protobuf.FieldOptions
+	9,  // 17: gapi.raw_data:extendee -> google.protobuf.FieldOptions
+	9,  // 18: gapi.from_context:extendee -> google.protobuf.FieldOptions
+	9,  // 19: gapi.validate:extendee -> google.protobuf.FieldOptions
+	9,  // 20: gapi.bind:extendee -> google.protobuf.FieldOptions
+	9,  // 21: gapi.enum_as_string:extendee -> google.protobuf.FieldOptions
+	9,  // 22: gapi.int64_as_string:extendee -> google.protobuf.FieldOptions
+	2,  // 23: gapi.http:type_name -> gapi.Http
+	4,  // 24: gapi.default_retry:type_name -> gapi.Retry
+	0,  // 25: gapi.json_naming:type_name -> gapi.JSON_NAMING
+	1,  // 26: gapi.bind:type_name -> gapi.FIELD_BIND
+	27, // [27:27] is the sub-list for method output_type
+	27, // [27:27] is the sub-list for method input_type
+	23, // [23:27] is the sub-list for extension type_name
+	4,  // [4:23] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_annotation_proto_init() }
@@ -524,6 +1074,42 @@ func file_annotation_proto_init() {
 				return nil
 			}
 		}
+		file_annotation_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Limits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_annotation_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Retry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_annotation_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_annotation_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*Http_Post)(nil),
@@ -538,9 +1124,9 @@ func file_annotation_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_annotation_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   1,
-			NumExtensions: 12,
+			NumEnums:      2,
+			NumMessages:   4,
+			NumExtensions: 19,
 			NumServices:   0,
 		},
 		GoTypes:           file_annotation_proto_goTypes,
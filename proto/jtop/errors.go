@@ -0,0 +1,96 @@
+package jtop
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error is an error of decoding, which is located by Path and Offset.
+type Error struct {
+	// Path of the value, e.g. $.items[3].price.
+	Path string
+	// Offset is the byte offset of the value in the input.
+	Offset int
+	// Kind is the protobuf kind of the value, it's InvalidType if the error
+	// isn't about a field.
+	Kind   metadata.TypeKind
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return "invalid json at " + e.Path + " (offset " + strconv.Itoa(e.Offset) + "): " + e.Reason
+}
+
+// GRPCStatus returns an InvalidArgument status with the location of the
+// error in a BadRequest detail.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(codes.InvalidArgument, e.Error())
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       e.Path,
+			Description: e.Reason,
+		}},
+	}); err == nil {
+		st = detailed
+	}
+	return st
+}
+
+type pathElem struct {
+	key   string
+	index int
+}
+
+// state is shared by encoders of nested values in a decoding.
+type state struct {
+	opts Options
+	path []pathElem
+}
+
+func (s *state) pushKey(key string) {
+	s.path = append(s.path, pathElem{key: key, index: -1})
+}
+
+func (s *state) pushIndex(i int) {
+	s.path = append(s.path, pathElem{index: i})
+}
+
+func (s *state) pop() {
+	s.path = s.path[:len(s.path)-1]
+}
+
+func (s *state) pathString() string {
+	var b strings.Builder
+	b.WriteByte('$')
+	for _, elem := range s.path {
+		switch {
+		case elem.index >= 0:
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(elem.index))
+			b.WriteByte(']')
+		case isPlainKey(elem.key):
+			b.WriteByte('.')
+			b.WriteString(elem.key)
+		default:
+			b.WriteByte('[')
+			b.WriteString(strconv.Quote(elem.key))
+			b.WriteByte(']')
+		}
+	}
+	return b.String()
+}
+
+func isPlainKey(key string) bool {
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '@' || i > 0 && c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return key != ""
+}
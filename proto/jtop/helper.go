@@ -21,6 +21,7 @@ var numericKinds = [...]bool{
 	metadata.Sfixed32Kind: true,
 	metadata.Sfixed64Kind: true,
 	metadata.BoolKind:     true,
+	metadata.EnumKind:     true,
 }
 
 func isNumeric(kind metadata.TypeKind) bool {
@@ -30,8 +31,24 @@ func isNumeric(kind metadata.TypeKind) bool {
 	return numericKinds[kind]
 }
 
+// isQuotedNumber reports whether strings of field are numbers, enums are
+// quoted by names instead.
+func isQuotedNumber(field *metadata.Field) bool {
+	return isNumeric(field.Kind) && field.Kind != metadata.EnumKind && field.Kind != metadata.BoolKind
+}
+
+func containsField(fields []*metadata.Field, field *metadata.Field) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
 func fieldNullable(filed *metadata.Field) bool {
 	return filed.Repeated ||
+		filed.Optional ||
 		filed.Kind == metadata.BytesKind ||
 		filed.Kind == metadata.MapKind ||
 		filed.Kind == metadata.MessageKind
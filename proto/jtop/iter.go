@@ -2,6 +2,7 @@ package jtop
 
 import (
 	"fmt"
+	"io"
 )
 
 type TokenKind uint8
@@ -39,6 +40,8 @@ var tokenMap = [...]string{
 type Token struct {
 	Kind  TokenKind
 	Value []byte
+	// offset of the token in the input
+	off int
 }
 
 func (t *Token) String() string {
@@ -50,6 +53,37 @@ type Iter struct {
 	buf     []byte
 	token   Token // only hold the last token
 	topKind TokenKind
+	top     bool
+	// the input read from r is buffered in a window which is reused, base is
+	// the offset of buf in the input
+	r    io.Reader
+	rerr error
+	base int
+	// start of the token being read
+	start int
+	// offset kept for a look-ahead, -1 if none
+	keep int
+	// the token being read reached the end of buf
+	short bool
+	// maxToken bounds the growth of the window, maxInput bounds the bytes
+	// read from r, zero is unlimited
+	maxToken int
+	maxInput int64
+}
+
+// readWindow is the size of the window of a reader, it only grows for a token
+// which doesn't fit in.
+const readWindow = 32 << 10
+
+// windowError is an error of a token or look-ahead which doesn't fit in the
+// window of a reader.
+type windowError struct {
+	offset int
+	reason string
+}
+
+func (e *windowError) Error() string {
+	return e.reason
 }
 
 type iterFunc func(*Iter) *Token
@@ -79,22 +113,132 @@ var iterMatch = [...]iterFunc{
 }
 
 func NewIter(b []byte) *Iter {
-	return &Iter{pos: 0, buf: b, token: Token{Kind: Invalid}}
+	return &Iter{pos: 0, buf: b, token: Token{Kind: Invalid}, keep: -1}
+}
+
+// NewReaderIter returns an Iter which reads the input from r incrementally.
+// Values of tokens are only valid until the next call of Next or Consume.
+func NewReaderIter(r io.Reader) *Iter {
+	return &Iter{r: r, buf: make([]byte, 0, readWindow), token: Token{Kind: Invalid}, keep: -1}
 }
 
 func (i *Iter) Next() bool {
 	i.skipWhiteSpace()
+	for i.eof() {
+		i.start = i.pos
+		if !i.fill() {
+			break
+		}
+		i.skipWhiteSpace()
+	}
 	return !i.eof()
 }
 
 func (i *Iter) Consume() *Token {
+	i.start = i.pos
 	fn := iterMatch[i.buf[i.pos]]
-	if fn != nil {
-		return fn(i)
+	if fn == nil {
+		return i.setToken(Invalid, i.buf[i.pos:])
 	}
-	i.token.Kind = Invalid
-	i.token.Value = i.buf[i.pos:]
-	return &i.token
+	for {
+		i.short = false
+		t := fn(i)
+		if !i.short || i.r == nil {
+			return t
+		}
+		// the token is resumed after more input is read, it's read again
+		// without more input as the window has moved
+		if !i.fill() {
+			i.short = false
+			return fn(i)
+		}
+	}
+}
+
+// Err returns the error of reading the input, except io.EOF.
+func (i *Iter) Err() error {
+	if i.rerr == io.EOF {
+		return nil
+	}
+	return i.rerr
+}
+
+// fill moves the unconsumed input to the beginning of the window and reads
+// until the window is full. The window grows only if the token being read
+// fills it, a look-ahead has to fit in it.
+func (i *Iter) fill() bool {
+	if i.r == nil || i.rerr != nil {
+		return false
+	}
+	from := i.start
+	if i.keep >= 0 && i.keep-i.base < from {
+		from = i.keep - i.base
+	}
+	n := len(i.buf) - from
+	buf := i.buf[:cap(i.buf)]
+	switch {
+	case len(buf) > readWindow && n < readWindow/2:
+		// shrink the window grown for a long token
+		buf = make([]byte, readWindow)
+		copy(buf, i.buf[from:])
+	case n < len(buf):
+		copy(buf, i.buf[from:])
+	default:
+		if i.keep >= 0 {
+			i.rerr = &windowError{offset: i.keep, reason: fmt.Sprintf("look-ahead for @type of google.protobuf.Any exceeds %d bytes", len(buf))}
+			return false
+		}
+		size := 2 * len(buf)
+		if i.maxToken > 0 && size > i.maxToken {
+			size = i.maxToken
+		}
+		if n >= size {
+			i.rerr = &windowError{offset: i.base + i.start, reason: fmt.Sprintf("exceeds max string length %d", i.maxToken-2)}
+			return false
+		}
+		buf = make([]byte, size)
+		copy(buf, i.buf[from:])
+	}
+	if i.maxInput > 0 {
+		// one more byte to find the input exceeds
+		if room := i.maxInput + 1 - int64(i.base+len(i.buf)); room < int64(len(buf)-n) {
+			buf = buf[:n+int(room)]
+		}
+	}
+	m, err := io.ReadFull(i.r, buf[n:])
+	switch {
+	case err == io.ErrUnexpectedEOF:
+		err = io.EOF
+	case err == nil && i.maxInput > 0 && int64(i.base+len(i.buf)+m) > i.maxInput:
+		err = &windowError{offset: int(i.maxInput), reason: fmt.Sprintf("exceeds max body bytes %d", i.maxInput)}
+	}
+	i.rerr = err
+	i.buf = buf[:n+m]
+	i.base += from
+	i.pos -= from
+	i.start -= from
+	return m > 0
+}
+
+// offset returns the offset of a token in the input, or the current offset
+// if t is nil.
+func (i *Iter) offset(t *Token) int {
+	if t == nil {
+		return i.base + i.pos
+	}
+	return t.off
+}
+
+// mark keeps the input from the current position for a look-ahead, which
+// ends by rewinding to the mark.
+func (i *Iter) mark() int {
+	i.keep = i.base + i.pos
+	return i.keep
+}
+
+func (i *Iter) rewind(mark int) {
+	i.pos = mark - i.base
+	i.keep = -1
 }
 
 func (i *Iter) ConsumeKind() TokenKind {
@@ -102,13 +246,18 @@ func (i *Iter) ConsumeKind() TokenKind {
 }
 
 func (i *Iter) TopKind() TokenKind {
-	if i.pos != 0 {
+	if i.top {
 		return i.topKind
 	}
-	token := i.Consume()
-	i.topKind = token.Kind
-	i.pos = 0
-	return token.Kind
+	i.top = true
+	if !i.Next() {
+		i.topKind = Invalid
+		return Invalid
+	}
+	i.topKind = i.Consume().Kind
+	// the token is kept in the window
+	i.pos = i.start
+	return i.topKind
 }
 
 func (i *Iter) Bytes() []byte {
@@ -118,6 +267,12 @@ func (i *Iter) Bytes() []byte {
 func (i *Iter) SetBuf(b []byte) {
 	i.buf = b
 	i.pos = 0
+	i.top = false
+	i.r = nil
+	i.rerr = nil
+	i.base = 0
+	i.start = 0
+	i.keep = -1
 }
 
 func (i *Iter) skipWhiteSpace() {
@@ -142,12 +297,14 @@ func (i *Iter) eof() bool {
 func (i *Iter) setToken(kind TokenKind, val []byte) *Token {
 	i.token.Kind = kind
 	i.token.Value = val
+	i.token.off = i.base + cap(i.buf) - cap(val)
 	return &i.token
 }
 
 func makeFixedIter(kind TokenKind, size int) iterFunc {
 	return func(i *Iter) *Token {
 		if !i.request(size - 1) {
+			i.short = true
 			return i.setToken(Invalid, i.buf[i.pos:])
 		}
 		begin := i.pos
@@ -165,7 +322,7 @@ func makeFixed1Iter(kind TokenKind) iterFunc {
 }
 
 func iterNumber(i *Iter) *Token {
-	begin := i.pos
+	begin := i.start
 	for i.pos < len(i.buf) {
 		c := i.buf[i.pos]
 		if c >= '0' && c <= '9' || c == '-' || c == '.' || c == 'e' || c == 'E' {
@@ -174,12 +331,16 @@ func iterNumber(i *Iter) *Token {
 		}
 		break
 	}
+	i.short = i.eof()
 	return i.setToken(Number, i.buf[begin:i.pos])
 }
 
 func iterString(i *Iter) *Token {
-	begin := i.pos
-	i.pos++
+	begin := i.start
+	if i.pos == begin {
+		// the opening quote
+		i.pos++
+	}
 	for i.pos < len(i.buf) {
 		c := i.buf[i.pos]
 		if c == '"' && i.buf[i.pos-1] != '\\' {
@@ -188,5 +349,6 @@ func iterString(i *Iter) *Token {
 		}
 		i.pos++
 	}
+	i.short = true
 	return i.setToken(Invalid, i.buf[begin:])
 }
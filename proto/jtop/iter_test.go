@@ -1,7 +1,10 @@
 package jtop
 
 import (
+	"strconv"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 const data = "{\"animals\":{\"dog\":[{\"name\":\"Rufus\",\"age\":15},{\"name\":\"Marty\",\"age\":null}]}}"
@@ -16,3 +19,35 @@ func TestIter_Consume(t *testing.T) {
 		}
 	}
 }
+
+func TestReaderIter(t *testing.T) {
+	var b strings.Builder
+	b.WriteString(`{"long":"` + strings.Repeat("x", readWindow+10) + `","nums":[`)
+	for n := 0; n < 20000; n++ {
+		if n > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Itoa(n))
+	}
+	b.WriteString(`],"t":true,"f":false,"n":null}`)
+	in := b.String()
+
+	want := NewIter([]byte(in))
+	// the window is filled byte by byte
+	iter := NewReaderIter(iotest.OneByteReader(strings.NewReader(in)))
+	for want.Next() {
+		if !iter.Next() {
+			t.Fatal("unexpected end of input")
+		}
+		w, tk := want.Consume(), iter.Consume()
+		if w.Kind != tk.Kind || string(w.Value) != string(tk.Value) || w.off != tk.off {
+			t.Fatalf("got %s at %d, want %s at %d", tk, tk.off, w, w.off)
+		}
+	}
+	if iter.Next() || iter.Err() != nil {
+		t.Fatalf("unexpected end: %v", iter.Err())
+	}
+	if cap(iter.buf) != readWindow {
+		t.Fatalf("window isn't shrunk: %d", cap(iter.buf))
+	}
+}
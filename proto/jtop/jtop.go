@@ -10,6 +10,7 @@ import (
 	"io"
 	"math"
 	"strconv"
+	"strings"
 	"sync"
 	"unsafe"
 )
@@ -18,7 +19,20 @@ type Encoder struct {
 	buf       *proto.Buffer
 	err       error
 	iter      *Iter
+	st        *state
 	rootField *metadata.Field // cache
+	// inAny is set for the object of a packed message, which has @type
+	inAny bool
+}
+
+// Options are options of decoding.
+type Options struct {
+	// Strict rejects unknown fields and duplicated fields.
+	Strict bool
+	// Limits bound the input, MaxBodyBytes only applies to EncodeReader.
+	// Values of unknown fields are skipped without recursion, they aren't
+	// checked.
+	Limits metadata.Limits
 }
 
 var encoderPool sync.Pool
@@ -36,16 +50,48 @@ func putEncoder(enc *Encoder) {
 	encoderPool.Put(enc)
 }
 
+// Encode encodes a JSON object as msg, errors of the input are *Error.
 func Encode(msg *metadata.Message, data []byte) ([]byte, error) {
+	return Options{}.Encode(msg, data)
+}
+
+// EncodeReader encodes a JSON object read from r as msg, the input isn't
+// buffered as a whole. Errors of reading r are returned as is.
+func EncodeReader(msg *metadata.Message, r io.Reader) ([]byte, error) {
+	return Options{}.EncodeReader(msg, r)
+}
+
+func (o Options) Encode(msg *metadata.Message, data []byte) ([]byte, error) {
+	return o.encode(msg, NewIter(data))
+}
+
+func (o Options) EncodeReader(msg *metadata.Message, r io.Reader) ([]byte, error) {
+	iter := NewReaderIter(r)
+	if o.Limits.MaxStringLength > 0 {
+		// quoted
+		iter.maxToken = o.Limits.MaxStringLength + 2
+	}
+	iter.maxInput = o.Limits.MaxBodyBytes
+	return o.encode(msg, iter)
+}
+
+func (o Options) encode(msg *metadata.Message, iter *Iter) ([]byte, error) {
 	enc := newEncoder()
 	defer putEncoder(enc)
-	iter := NewIter(data)
+	enc.reset(iter, &state{opts: o})
 	if iter.TopKind() != ObjectBegin {
-		return nil, errors.New("invalid json input: must be json object")
+		enc.fail(nil, nil, "must be json object")
+	} else {
+		enc.rootField.Message = msg
+		enc.transValue(enc.rootField)
+	}
+	// the input may be truncated by the error
+	if err := iter.Err(); err != nil {
+		if we, ok := err.(*windowError); ok {
+			return nil, &Error{Path: enc.st.pathString(), Offset: we.offset, Reason: we.reason}
+		}
+		return nil, err
 	}
-	enc.reset(iter)
-	enc.rootField.Message = msg
-	enc.transValue(enc.rootField)
 	if enc.err != nil {
 		return nil, enc.err
 	}
@@ -53,8 +99,10 @@ func Encode(msg *metadata.Message, data []byte) ([]byte, error) {
 	return buf, nil
 }
 
-func (e *Encoder) reset(iter *Iter) {
+func (e *Encoder) reset(iter *Iter, st *state) {
 	e.iter = iter
+	e.st = st
+	e.inAny = false
 	if e.rootField == nil {
 		e.rootField = &metadata.Field{Name: "root_field", Kind: metadata.MessageKind}
 	}
@@ -66,23 +114,76 @@ func (e *Encoder) reset(iter *Iter) {
 	e.buf.Reset()
 }
 
-func (e *Encoder) setErrorMissMatch(jsonType string, pbKind metadata.TypeKind) {
-	e.err = fmt.Errorf("type miss match: json type (%s) and protobuf kind (%d)", jsonType, pbKind)
+// fail sets an *Error located at the token, or the current position if
+// token is nil.
+func (e *Encoder) fail(token *Token, field *metadata.Field, format string, args ...interface{}) {
+	err := &Error{
+		Path:   e.st.pathString(),
+		Offset: e.iter.offset(token),
+		Reason: fmt.Sprintf(format, args...),
+	}
+	if field != nil {
+		err.Kind = field.Kind
+	}
+	e.err = err
 }
 
-func (e *Encoder) setErrorInvalidJsonToken(token *Token, err error) {
+// checkDepth fails if an object or array at the current path is nested too
+// deep.
+func (e *Encoder) checkDepth(token *Token) bool {
+	if max := e.st.opts.Limits.MaxDepth; max > 0 && len(e.st.path) >= max {
+		e.fail(token, nil, "exceeds max depth %d", max)
+		return false
+	}
+	return true
+}
+
+func (e *Encoder) checkArrayLength(token *Token, n int) bool {
+	if max := e.st.opts.Limits.MaxArrayLength; max > 0 && n > max {
+		e.fail(token, nil, "exceeds max array length %d", max)
+		return false
+	}
+	return true
+}
+
+func (e *Encoder) checkMapEntries(token *Token, n int) bool {
+	if max := e.st.opts.Limits.MaxMapEntries; max > 0 && n > max {
+		e.fail(token, nil, "exceeds max map entries %d", max)
+		return false
+	}
+	return true
+}
+
+func (e *Encoder) checkString(token *Token) bool {
+	if max := e.st.opts.Limits.MaxStringLength; max > 0 && len(token.Value)-2 > max {
+		e.fail(token, nil, "exceeds max string length %d", max)
+		return false
+	}
+	return true
+}
+
+func (e *Encoder) setErrorMissMatch(token *Token, jsonType string, field *metadata.Field) {
+	if field.Kind == metadata.MessageKind {
+		e.fail(token, field, "json %s can't be decoded as message %s", jsonType, strings.TrimPrefix(field.Message.Name, "."))
+		return
+	}
+	e.fail(token, field, "json %s can't be decoded as %s", jsonType, field.Kind)
+}
+
+func (e *Encoder) setErrorInvalidJsonToken(token *Token, field *metadata.Field, err error) {
 	if err == nil {
-		err = errors.New("")
+		e.fail(token, field, "invalid value %s", token.Value)
+		return
 	}
-	e.err = fmt.Errorf("invalid json input value: [%s] :%s", token.Value, err)
+	e.fail(token, field, "invalid value %s: %v", token.Value, err)
 }
 
 func (e *Encoder) setErrorInvalidJsonFormat(err error) {
 	if err != nil {
-		e.err = fmt.Errorf("invalid json format :%s", err)
+		e.fail(nil, nil, "invalid json format: %v", err)
 		return
 	}
-	e.err = fmt.Errorf("invalid json format")
+	e.fail(nil, nil, "invalid json format")
 }
 
 func (e *Encoder) encodeBytes(tag int, v []byte) {
@@ -109,7 +210,7 @@ func (e *Encoder) encodeKey(tag int, wire protowire.Type) {
 
 func (e *Encoder) transBool(token *Token, field *metadata.Field) {
 	if field.Kind != metadata.BoolKind {
-		e.setErrorMissMatch("bool", field.Kind)
+		e.setErrorMissMatch(token, "bool", field)
 		return
 	}
 	wire, pv, ok := e.parseNumber(token, field)
@@ -122,14 +223,14 @@ func (e *Encoder) transBool(token *Token, field *metadata.Field) {
 
 func (e *Encoder) transNull(token *Token, field *metadata.Field) {
 	if !fieldNullable(field) {
-		e.setErrorMissMatch("null", field.Kind)
+		e.setErrorMissMatch(token, "null", field)
 		return
 	}
 	v := token.Value
 	if v[0] == 'n' && v[1] == 'u' && v[2] == 'l' && v[3] == 'l' {
 		return
 	}
-	e.err = fmt.Errorf("invalid json input value: %s", token.Value)
+	e.setErrorInvalidJsonToken(token, field, nil)
 }
 
 func (e *Encoder) transNumber(token *Token, field *metadata.Field) {
@@ -142,12 +243,24 @@ func (e *Encoder) transNumber(token *Token, field *metadata.Field) {
 }
 
 func (e *Encoder) transString(token *Token, field *metadata.Field) {
+	if isQuotedNumber(field) {
+		e.transNumber(e.unquoteNumber(token), field)
+		return
+	}
 	var pv []byte
 	switch field.Kind {
+	case metadata.EnumKind:
+		x, ok := e.parseEnumName(token, field)
+		if !ok {
+			return
+		}
+		e.encodeKey(field.Tag, protowire.VarintType)
+		e.encodeWire(protowire.VarintType, x)
+		return
 	case metadata.StringKind:
 		s, ok := e.unquoteString(token.Value)
 		if !ok {
-			e.setErrorInvalidJsonToken(token, errors.New("invalid string format"))
+			e.setErrorInvalidJsonToken(token, field, errors.New("invalid string format"))
 			return
 		}
 		pv = s
@@ -156,32 +269,42 @@ func (e *Encoder) transString(token *Token, field *metadata.Field) {
 		b := make([]byte, maxLen)
 		l, err := base64.StdEncoding.Decode(b, token.Value[1:len(token.Value)-1])
 		if err != nil {
-			e.setErrorInvalidJsonToken(token, err)
+			e.setErrorInvalidJsonToken(token, field, err)
 			return
 		}
 		pv = b[:l]
 	default:
-		e.setErrorMissMatch("string", field.Kind)
+		e.setErrorMissMatch(token, "string", field)
 		return
 	}
 	e.encodeBytes(field.Tag, pv)
 }
 
-func (e *Encoder) transObjectAsMap(_ *Token, field *metadata.Field) {
+func (e *Encoder) transObjectAsMap(token *Token, field *metadata.Field) {
 	msg := field.Message
 	if len(msg.Fields) != 2 {
 		e.err = errors.New("invalid metadata: map type error")
 		return
 	}
+	if !e.checkDepth(token) {
+		return
+	}
 
 	var kvField = [...]*metadata.Field{msg.Fields[0], msg.Fields[1]}
 	kvEnc := newEncoder()
-	kvEnc.reset(e.iter)
+	kvEnc.reset(e.iter, e.st)
 	done := false
+	// keys are tracked by the strict mode only
+	var keys map[string]bool
+	if e.st.opts.Strict {
+		keys = map[string]bool{}
+	}
+	depth := len(e.st.path)
+	entries := 0
 
 KvEncode:
 	for {
-		kvEnc.reset(e.iter)
+		kvEnc.reset(e.iter, e.st)
 		for i := 0; i < 2; i++ {
 			field := kvField[i]
 			kind, ok := kvEnc.transValue(field)
@@ -194,10 +317,29 @@ KvEncode:
 			}
 			if !isValueToken(kind) {
 				i--
+				continue
+			}
+			if i == 0 {
+				entries++
+				if !kvEnc.checkMapEntries(&e.iter.token, entries) {
+					break KvEncode
+				}
+				// the key is the last token
+				key, _ := unquoteBytes(e.iter.token.Value)
+				e.st.pushKey(string(key))
+				if keys != nil {
+					if keys[string(key)] {
+						kvEnc.fail(&e.iter.token, nil, "duplicated key")
+						break KvEncode
+					}
+					keys[string(key)] = true
+				}
 			}
 		}
+		e.st.pop()
 		e.encodeBytes(field.Tag, kvEnc.buf.Bytes())
 	}
+	e.st.path = e.st.path[:depth]
 
 	if !done && kvEnc.err == nil {
 		e.setErrorInvalidJsonFormat(io.ErrUnexpectedEOF)
@@ -216,21 +358,29 @@ func (e *Encoder) transObject(token *Token, field *metadata.Field) {
 		return
 	}
 	if field.Kind != metadata.MessageKind {
-		e.setErrorMissMatch("object", field.Kind)
+		e.setErrorMissMatch(token, "object", field)
 		return
 	}
 
+	if !e.checkDepth(token) {
+		return
+	}
 	msg := field.Message
 	objEnc := e
 	root := e.rootField.Message != nil
 	if !root {
 		objEnc = newEncoder()
-		objEnc.reset(e.iter)
+		objEnc.reset(e.iter, e.st)
 	} else {
 		e.rootField.Message = nil
 	}
 
 	done := false
+	// members of oneof that have been set
+	var oneofSet []*metadata.Field
+	// fields that have been set, which are tracked by the strict mode only
+	var fieldSet []*metadata.Field
+	strict := e.st.opts.Strict
 
 	for objEnc.iter.Next() {
 		tk := objEnc.iter.Consume()
@@ -242,22 +392,47 @@ func (e *Encoder) transObject(token *Token, field *metadata.Field) {
 			continue
 		}
 		if tk.Kind != String {
-			objEnc.setErrorInvalidJsonToken(tk, errors.New("unexpected key"))
+			objEnc.setErrorInvalidJsonToken(tk, nil, errors.New("unexpected key"))
 			break
 		}
 		// read key
 		key, ok := objEnc.unquoteString(tk.Value)
 		if !ok {
-			objEnc.setErrorInvalidJsonToken(tk, errors.New("invalid string format"))
+			objEnc.setErrorInvalidJsonToken(tk, nil, errors.New("invalid string format"))
 			break
 		}
+		// the key isn't kept in the window of a reader
+		name := string(key)
+		keyToken := *tk
 		objEnc.ignoreToken()
-		field := msg.GetField(string(key))
+		e.st.pushKey(name)
+		field := msg.GetField(name)
 		if field != nil {
-			objEnc.transValue(field)
+			if strict {
+				if containsField(fieldSet, field) {
+					objEnc.fail(&keyToken, field, "duplicated field %s", field.Name)
+					break
+				}
+				fieldSet = append(fieldSet, field)
+			}
+			if field.Oneof != nil {
+				if !objEnc.checkOneof(oneofSet, field) {
+					break
+				}
+				oneofSet = append(oneofSet, field)
+			}
+			if _, ok := objEnc.transValue(field); !ok {
+				break
+			}
+			e.st.pop()
 			continue
 		}
+		if strict && !(objEnc.inAny && name == "@type") {
+			objEnc.fail(&keyToken, nil, "unknown field of %s", strings.TrimPrefix(msg.Name, "."))
+			break
+		}
 		objEnc.ignoreValueTokens()
+		e.st.pop()
 	}
 	if !done && objEnc.err == nil {
 		objEnc.setErrorInvalidJsonFormat(io.ErrUnexpectedEOF)
@@ -265,7 +440,9 @@ func (e *Encoder) transObject(token *Token, field *metadata.Field) {
 
 	if objEnc.err != nil {
 		e.err = objEnc.err
-		putEncoder(objEnc)
+		if !root {
+			putEncoder(objEnc)
+		}
 		return
 	}
 
@@ -275,19 +452,57 @@ func (e *Encoder) transObject(token *Token, field *metadata.Field) {
 	}
 }
 
+func (e *Encoder) checkOneof(set []*metadata.Field, field *metadata.Field) bool {
+	for _, f := range set {
+		if f.Oneof == field.Oneof && f != field {
+			e.fail(nil, field, "fields %s and %s of oneof %s are both set", f.Name, field.Name, field.Oneof.Name)
+			return false
+		}
+	}
+	return true
+}
+
 func (e *Encoder) packNumeric(_ *Token, field *metadata.Field) {
 	packEnc := newEncoder()
-	packEnc.reset(e.iter)
+	packEnc.reset(e.iter, e.st)
+	depth := len(e.st.path)
+	defer func() { e.st.path = e.st.path[:depth] }()
+	index := 0
 	for packEnc.iter.Next() {
 		tk := packEnc.iter.Consume()
 		if tk.Kind == ArrayEnd {
 			break
 		}
+		if tk.Kind == Comma {
+			continue
+		}
+		index++
+		if !packEnc.checkArrayLength(tk, index) {
+			e.err = packEnc.err
+			putEncoder(packEnc)
+			return
+		}
+		e.st.path = append(e.st.path[:depth], pathElem{index: index - 1})
+		if tk.Kind == String && field.Kind == metadata.EnumKind {
+			pv, ok := packEnc.parseEnumName(tk, field)
+			if !ok {
+				e.err = packEnc.err
+				putEncoder(packEnc)
+				return
+			}
+			packEnc.encodeWire(protowire.VarintType, pv)
+			continue
+		}
+		if tk.Kind == String && isQuotedNumber(field) {
+			tk = packEnc.unquoteNumber(tk)
+		}
 		if tk.Kind != Number && tk.Kind != True && tk.Kind != False {
 			continue
 		}
 		wire, pv, ok := packEnc.parseNumber(tk, field)
 		if !ok {
+			e.err = packEnc.err
+			putEncoder(packEnc)
 			return
 		}
 		packEnc.encodeWire(wire, pv)
@@ -298,7 +513,10 @@ func (e *Encoder) packNumeric(_ *Token, field *metadata.Field) {
 
 func (e *Encoder) transArray(token *Token, field *metadata.Field) {
 	if !field.Repeated {
-		e.setErrorMissMatch("array", field.Kind)
+		e.setErrorMissMatch(token, "array", field)
+		return
+	}
+	if !e.checkDepth(token) {
 		return
 	}
 	// https://developers.google.com/protocol-buffers/docs/encoding#packed
@@ -308,12 +526,28 @@ func (e *Encoder) transArray(token *Token, field *metadata.Field) {
 		return
 	}
 
-	for {
-		kind, ok := e.transValue(field)
-		if !ok || kind == ArrayEnd || kind == Invalid {
+	depth := len(e.st.path)
+	index := 0
+	for e.iter.Next() && e.err == nil {
+		tk := e.iter.Consume()
+		if tk.Kind == ArrayEnd {
 			break
 		}
+		if tk.Kind == Comma {
+			continue
+		}
+		if !e.checkArrayLength(tk, index+1) {
+			break
+		}
+		e.st.pushIndex(index)
+		index++
+		kind, ok := e.transToken(tk, field)
+		if !ok || kind == Invalid {
+			break
+		}
+		e.st.pop()
 	}
+	e.st.path = e.st.path[:depth]
 }
 
 func (e *Encoder) transValue(filed *metadata.Field) (TokenKind, bool) {
@@ -321,10 +555,22 @@ func (e *Encoder) transValue(filed *metadata.Field) (TokenKind, bool) {
 		return Invalid, false
 	}
 	token := e.iter.Consume()
+	return e.transToken(token, filed)
+}
+
+func (e *Encoder) transToken(token *Token, filed *metadata.Field) (TokenKind, bool) {
 	kind := token.Kind
+	if kind == String && !e.checkString(token) {
+		return kind, false
+	}
+	if filed.Kind == metadata.MessageKind && filed.Message.WellKnown != metadata.NotWellKnown &&
+		isValueToken(kind) && (kind != ArrayBegin || !filed.Repeated) {
+		e.transWellKnown(token, filed)
+		return kind, e.err == nil
+	}
 	switch token.Kind {
 	case Invalid:
-		e.setErrorInvalidJsonToken(token, nil)
+		e.setErrorInvalidJsonToken(token, filed, nil)
 	case Null:
 		e.transNull(token, filed)
 	case True, False:
@@ -339,7 +585,7 @@ func (e *Encoder) transValue(filed *metadata.Field) (TokenKind, bool) {
 		e.transArray(token, filed)
 	case ObjectEnd, ArrayEnd, Comma, Colon:
 	default:
-		e.setErrorInvalidJsonToken(token, nil)
+		e.setErrorInvalidJsonToken(token, filed, nil)
 	}
 	return kind, e.err == nil
 }
@@ -348,6 +594,12 @@ func (e *Encoder) unquoteString(data []byte) ([]byte, bool) {
 	return unquoteBytes(data)
 }
 
+// unquoteNumber returns the number token in a string token, e.g. "123",
+// which is used by 64-bit integers.
+func (e *Encoder) unquoteNumber(token *Token) *Token {
+	return &Token{Kind: Number, Value: token.Value[1 : len(token.Value)-1], off: token.off + 1}
+}
+
 func (e *Encoder) parseNumber(token *Token, field *metadata.Field) (wire protowire.Type, pv uint64, ok bool) {
 	wire = protowire.VarintType
 	sval := *(*string)(unsafe.Pointer(&token.Value))
@@ -363,7 +615,7 @@ func (e *Encoder) parseNumber(token *Token, field *metadata.Field) (wire protowi
 		var fv float64
 		fv, err = strconv.ParseFloat(sval, 32)
 		pv = uint64(math.Float32bits(float32(fv)))
-	case metadata.Int32Kind:
+	case metadata.Int32Kind, metadata.EnumKind:
 		var fv int64
 		fv, err = strconv.ParseInt(sval, 10, 32)
 		pv = uint64(fv)
@@ -411,17 +663,35 @@ func (e *Encoder) parseNumber(token *Token, field *metadata.Field) (wire protowi
 		}
 		err = fmt.Errorf("invalid value: %s", token.Value)
 	default:
-		e.setErrorMissMatch("number", field.Kind)
+		e.setErrorMissMatch(token, "number", field)
 		err = e.err
 		return
 	}
 	if err != nil {
-		e.setErrorInvalidJsonToken(token, err)
+		e.setErrorInvalidJsonToken(token, field, err)
 		return
 	}
 	return wire, pv, true
 }
 
+func (e *Encoder) parseEnumName(token *Token, field *metadata.Field) (uint64, bool) {
+	name, ok := e.unquoteString(token.Value)
+	if !ok {
+		e.setErrorInvalidJsonToken(token, field, errors.New("invalid string format"))
+		return 0, false
+	}
+	if field.Enum == nil {
+		e.setErrorMissMatch(token, "string", field)
+		return 0, false
+	}
+	v := field.Enum.GetValue(string(name))
+	if v == nil {
+		e.setErrorInvalidJsonToken(token, field, fmt.Errorf("unknown value of enum %s", strings.TrimPrefix(field.Enum.Name, ".")))
+		return 0, false
+	}
+	return uint64(int64(v.Number)), true
+}
+
 func (e *Encoder) ignoreToken() {
 	if e.iter.Next() {
 		e.iter.ConsumeKind()
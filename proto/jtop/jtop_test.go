@@ -3,11 +3,19 @@ package jtop
 import (
 	"encoding/json"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/duration"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/zhiduoke/gapi/metadata"
 	"github.com/zhiduoke/gapi/proto/jtop/testdata"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"reflect"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 type protoreflecter interface {
@@ -52,6 +60,11 @@ func TestEncode(t *testing.T) {
 			in:   &mapReq1,
 			msg:  testdata.TestMessages[".jtop.test.MapReq"],
 		},
+		{
+			name: "enum",
+			in:   &enumReq,
+			msg:  testdata.TestMessages[".jtop.test.EnumReq"],
+		},
 	}
 	for _, c := range cases {
 		t.Logf("test %s\n", c.name)
@@ -80,8 +93,141 @@ func TestEncode(t *testing.T) {
 	}
 }
 
+func TestEncodeEnumName(t *testing.T) {
+	msg := testdata.TestMessages[".jtop.test.EnumReq"]
+	jsonData := []byte(`{"status":"ACTIVE","statuses":["BLOCKED",1,"DELETED"],"sme":{"a":"ACTIVE"}}`)
+	r, err := Encode(msg, jsonData)
+	if err != nil {
+		t.Fatalf("encode error: %s\n", err)
+	}
+	r1, err := proto.Marshal(proto.MessageV1(enumReq.ProtoReflect()))
+	if err != nil {
+		t.Fatalf("proto marshal error: %s\n", err)
+	}
+	if !reflect.DeepEqual(r, r1) {
+		diffbytes(t, r, r1)
+		t.Fatalf("protobuf not equal\n")
+	}
+
+	_, err = Encode(msg, []byte(`{"status":"NOT_EXISTS"}`))
+	if err == nil {
+		t.Fatal("expect error of unknown enum value")
+	}
+}
+
+func TestEncodeWellKnown(t *testing.T) {
+	msg := testdata.TestMessages[".jtop.test.WellKnownReq"]
+	jsonData := []byte(`{
+		"ts": "2020-09-13T12:26:40.5Z",
+		"dur": "-1.000000500s",
+		"i64": 64,
+		"str": "wrapped",
+		"bool": null,
+		"st": {"a": 1, "b": [true, "x", null], "c": {"d": {}}},
+		"val": "v",
+		"list": [1.5, {}],
+		"any": {"i32": 5, "@type": "type.googleapis.com/jtop.test.NumberReq"},
+		"any_wkt": {"@type": "type.googleapis.com/google.protobuf.Duration", "value": "2s"},
+		"tss": ["1970-01-01T00:00:01Z", "1970-01-01T08:00:02+08:00"]
+	}`)
+	r, err := Encode(msg, jsonData)
+	if err != nil {
+		t.Fatalf("encode error: %s\n", err)
+	}
+	var out testdata.WellKnownReq
+	err = proto.Unmarshal(r, proto.MessageV1(out.ProtoReflect()))
+	if err != nil {
+		t.Fatalf("proto unmarshal error: %s\n", err)
+	}
+	anyValue, _ := proto.Marshal(proto.MessageV1((&testdata.NumberReq{I32: 5}).ProtoReflect()))
+	anyWkt, _ := proto.Marshal(&duration.Duration{Seconds: 2})
+	expect := &testdata.WellKnownReq{
+		Ts:  &timestamp.Timestamp{Seconds: 1600000000, Nanos: 500000000},
+		Dur: &duration.Duration{Seconds: -1, Nanos: -500},
+		I64: &wrappers.Int64Value{Value: 64},
+		Str: &wrappers.StringValue{Value: "wrapped"},
+		St: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"a": {Kind: &structpb.Value_NumberValue{NumberValue: 1}},
+			"b": {Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: []*structpb.Value{
+				{Kind: &structpb.Value_BoolValue{BoolValue: true}},
+				{Kind: &structpb.Value_StringValue{StringValue: "x"}},
+				{Kind: &structpb.Value_NullValue{}},
+			}}}},
+			"c": {Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"d": {Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{}}},
+			}}}},
+		}},
+		Val: &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: "v"}},
+		List: &structpb.ListValue{Values: []*structpb.Value{
+			{Kind: &structpb.Value_NumberValue{NumberValue: 1.5}},
+			{Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{}}},
+		}},
+		Any:    &any.Any{TypeUrl: "type.googleapis.com/jtop.test.NumberReq", Value: anyValue},
+		AnyWkt: &any.Any{TypeUrl: "type.googleapis.com/google.protobuf.Duration", Value: anyWkt},
+		Tss:    []*timestamp.Timestamp{{Seconds: 1}, {Seconds: 2}},
+	}
+	if !proto.Equal(proto.MessageV1(out.ProtoReflect()), proto.MessageV1(expect.ProtoReflect())) {
+		t.Fatalf("protobuf not equal:\n%v\n%v", &out, expect)
+	}
+
+	for _, in := range []string{
+		`{"ts": 1}`,
+		`{"ts": "2020-09-13"}`,
+		`{"dur": "1m"}`,
+		`{"any": {"i32": 5}}`,
+		`{"any": {"@type": "type.googleapis.com/not.Exists"}}`,
+		`{"st": []}`,
+	} {
+		_, err := Encode(msg, []byte(in))
+		if err == nil {
+			t.Errorf("expect error of %s", in)
+		}
+	}
+}
+
+func TestEncodeOneof(t *testing.T) {
+	msg := testdata.TestMessages[".jtop.test.OneofReq"]
+	r, err := Encode(msg, []byte(`{"a":2,"num":{"i32":1}}`))
+	if err != nil {
+		t.Fatalf("encode error: %s\n", err)
+	}
+	r1, err := proto.Marshal(proto.MessageV1((&testdata.OneofReq{
+		Contact: &testdata.OneofReq_Num{Num: &testdata.NumberReq{I32: 1}},
+		A:       2,
+	}).ProtoReflect()))
+	if err != nil {
+		t.Fatalf("proto marshal error: %s\n", err)
+	}
+	if !reflect.DeepEqual(r, r1) {
+		diffbytes(t, r, r1)
+		t.Fatalf("protobuf not equal\n")
+	}
+
+	_, err = Encode(msg, []byte(`{"email":"a@b.c","a":2,"phone":"123"}`))
+	if err == nil {
+		t.Fatal("expect error of multiple oneof fields")
+	}
+	t.Log(err)
+}
+
+func TestEncodeOptional(t *testing.T) {
+	msg := testdata.TestMessages[".jtop.test.OptionalReq"]
+	r, err := Encode(msg, []byte(`{"a":0,"b":null,"c":0}`))
+	if err != nil {
+		t.Fatalf("encode error: %s\n", err)
+	}
+	var out testdata.OptionalReq
+	err = proto.Unmarshal(r, proto.MessageV1(out.ProtoReflect()))
+	if err != nil {
+		t.Fatalf("proto unmarshal error: %s\n", err)
+	}
+	if out.A == nil || *out.A != 0 || out.B != nil {
+		t.Fatalf("presence not preserved: %v", &out)
+	}
+}
+
 func Benchmark_JTOPEncode(b *testing.B) {
-	jsonData, _ := json.Marshal(objectReq)
+	jsonData, _ := json.Marshal(&objectReq)
 	msg := testdata.TestMessages[".jtop.test.ObjectReq"]
 	for i := 0; i < b.N; i++ {
 		Encode(msg, jsonData)
@@ -89,7 +235,7 @@ func Benchmark_JTOPEncode(b *testing.B) {
 }
 
 func Benchmark_ProtoEncode(b *testing.B) {
-	jsonData, _ := json.Marshal(objectReq)
+	jsonData, _ := json.Marshal(&objectReq)
 	o := new(testdata.ObjectReq)
 	for i := 0; i < b.N; i++ {
 		json.Unmarshal(jsonData, o)
@@ -178,3 +324,218 @@ var mapReq1 = testdata.MapReq{
 	//Imo: map[int32]*testdata.ObjectReq{0: &objectReq, 1: &objectReq1},
 	Sma: map[string]*testdata.ArrayReq{"a": &arrayReq},
 }
+
+var enumReq = testdata.EnumReq{
+	Status:   testdata.Status_ACTIVE,
+	Statuses: []testdata.Status{testdata.Status_BLOCKED, testdata.Status_ACTIVE, testdata.Status_DELETED},
+	Sme:      map[string]testdata.Status{"a": testdata.Status_ACTIVE},
+}
+
+func TestEncodeNaming(t *testing.T) {
+	msg := &metadata.Message{
+		Name: "testmsg",
+		Fields: []*metadata.Field{
+			{Tag: 1, Name: "user_id", JSONName: "uid", CamelName: "userId", Kind: metadata.Int32Kind},
+			// the original name of a field wins
+			{Tag: 2, Name: "uid", Kind: metadata.Int32Kind},
+		},
+	}
+	msg.BakeTagIndex()
+	msg.BakeNameField()
+	for key, tag := range map[string]byte{"user_id": 1, "userId": 1, "uid": 2} {
+		r, err := Encode(msg, []byte(`{"`+key+`":1}`))
+		if err != nil {
+			t.Fatalf("encode error: %s\n", err)
+		}
+		if !reflect.DeepEqual(r, []byte{tag << 3, 1}) {
+			t.Fatalf("%s: got %v", key, r)
+		}
+	}
+}
+
+func TestEncodeQuotedNumber(t *testing.T) {
+	msg := &metadata.Message{
+		Name: "testmsg",
+		Fields: []*metadata.Field{
+			{Tag: 1, Name: "a", Kind: metadata.Int64Kind},
+			{Tag: 2, Name: "b", Kind: metadata.Uint32Kind, Repeated: true},
+			{Tag: 3, Name: "c", Kind: metadata.DoubleKind},
+		},
+	}
+	msg.BakeTagIndex()
+	msg.BakeNameField()
+	r1, err := Encode(msg, []byte(`{"a":"-9007199254740993","b":["1",2],"c":"1.5"}`))
+	if err != nil {
+		t.Fatalf("encode error: %s\n", err)
+	}
+	r2, err := Encode(msg, []byte(`{"a":-9007199254740993,"b":[1,2],"c":1.5}`))
+	if err != nil {
+		t.Fatalf("encode error: %s\n", err)
+	}
+	if !reflect.DeepEqual(r1, r2) {
+		diffbytes(t, r1, r2)
+		t.Fatalf("protobuf not equal\n")
+	}
+	if _, err = Encode(msg, []byte(`{"a":"1x"}`)); err == nil {
+		t.Fatal("expect error of invalid number")
+	}
+}
+
+func TestEncodeStrict(t *testing.T) {
+	msg := testdata.TestMessages[".jtop.test.ObjectReq"]
+	strict := Options{Strict: true}
+	if _, err := strict.Encode(msg, []byte(`{"a":1,"num":{"i32":1}}`)); err != nil {
+		t.Fatalf("encode error: %s\n", err)
+	}
+	if _, err := Encode(msg, []byte(`{"a":1,"x":{"y":[1]}}`)); err != nil {
+		t.Fatalf("encode error: %s\n", err)
+	}
+	cases := map[string]string{
+		`{"a":1,"x":2}`:                 "$.x",
+		`{"num":{"i32":1,"i":2}}`:       "$.num.i",
+		`{"a":1,"b":true,"a":2}`:        "$.a",
+		`{"obj":{"obj":{"a":1,"a":1}}}`: "$.obj.obj.a",
+	}
+	for in, path := range cases {
+		_, err := strict.Encode(msg, []byte(in))
+		e, ok := err.(*Error)
+		if !ok {
+			t.Fatalf("%s: unexpected error %v", in, err)
+		}
+		if e.Path != path {
+			t.Fatalf("%s: got path %s", in, e.Path)
+		}
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	msg := testdata.TestMessages[".jtop.test.ArrayReq"]
+	in := `{"objs":[{"a":1},{"num":{"i64":"x"}}]}`
+	_, err := Encode(msg, []byte(in))
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if e.Path != "$.objs[1].num.i64" || e.Offset != strings.Index(in, `"x"`)+1 || e.Kind != metadata.Int64Kind {
+		t.Fatalf("unexpected error %+v", e)
+	}
+	if st := e.GRPCStatus(); st.Code() != codes.InvalidArgument || len(st.Details()) != 1 {
+		t.Fatalf("unexpected status %v", st)
+	}
+	t.Log(err)
+
+	_, err = Encode(msg, []byte(`{"nums":[1,2,"a"]}`))
+	if e, ok := err.(*Error); !ok || e.Path != "$.nums[2]" || e.Kind != metadata.Int32Kind {
+		t.Fatalf("unexpected error %v", err)
+	}
+	t.Log(err)
+}
+
+func TestEncodeLimits(t *testing.T) {
+	msg := testdata.TestMessages[".jtop.test.ArrayReq"]
+	limits := Options{Limits: metadata.Limits{
+		MaxDepth:        4,
+		MaxArrayLength:  2,
+		MaxMapEntries:   1,
+		MaxStringLength: 3,
+	}}
+	if _, err := limits.Encode(msg, []byte(`{"nums":[1,2],"objs":[{"a":1}],"strs":["abc"]}`)); err != nil {
+		t.Fatalf("encode error: %s\n", err)
+	}
+	cases := map[string]string{
+		`{"nums":[1,2,3]}`:                        "exceeds max array length 2",
+		`{"objs":[{"a":1},{"a":2},{"a":3}]}`:      "exceeds max array length 2",
+		`{"objs":[{"obj":{"obj":{}}}]}`:           "exceeds max depth 4",
+		`{"mapObjs":[{"sms":{"a":"1","b":"2"}}]}`: "exceeds max map entries 1",
+		`{"strs":["abcd"]}`:                       "exceeds max string length 3",
+	}
+	for in, reason := range cases {
+		_, err := limits.Encode(msg, []byte(in))
+		if e, ok := err.(*Error); !ok || e.Reason != reason {
+			t.Fatalf("%s: unexpected error %v", in, err)
+		}
+	}
+}
+
+func TestEncodeReader(t *testing.T) {
+	inputs := map[string]string{
+		".jtop.test.WellKnownReq": `{
+			"st": {"a": 1, "b": [true, "x", null], "c": {"d": {}}},
+			"any": {"i32": 5, "@type": "type.googleapis.com/jtop.test.NumberReq"},
+			"any_wkt": {"@type": "type.googleapis.com/google.protobuf.Duration", "value": "2s"}
+		}`,
+		".jtop.test.NumberReq": `{"i32": -12345, "i64": "9007199254740993", "double": 1.5e10, "float": 0.25}`,
+		".jtop.test.StringReq": `{"str": "a \"quoted\" string"}`,
+		".jtop.test.MapReq":    `{"sms": {"a": "b"}, "imo": {"1": {"a": 1, "b": true}}}`,
+	}
+	for name, in := range inputs {
+		msg := testdata.TestMessages[name]
+		r1, err := Encode(msg, []byte(in))
+		if err != nil {
+			t.Fatalf("%s: encode error: %s\n", name, err)
+		}
+		// the window is filled byte by byte
+		r2, err := EncodeReader(msg, iotest.OneByteReader(strings.NewReader(in)))
+		if err != nil {
+			t.Fatalf("%s: encode error: %s\n", name, err)
+		}
+		if !reflect.DeepEqual(r1, r2) {
+			diffbytes(t, r1, r2)
+			t.Fatalf("%s: protobuf not equal\n", name)
+		}
+	}
+
+	msg := testdata.TestMessages[".jtop.test.ArrayReq"]
+	in := `{"objs":[{"a":1},{"num":{"i64":"x"}}]}`
+	_, err := EncodeReader(msg, iotest.OneByteReader(strings.NewReader(in)))
+	if e, ok := err.(*Error); !ok || e.Path != "$.objs[1].num.i64" || e.Offset != strings.Index(in, `"x"`)+1 {
+		t.Fatalf("unexpected error %v", err)
+	}
+	_, err = EncodeReader(msg, iotest.TimeoutReader(strings.NewReader(in[:10])))
+	if err != iotest.ErrTimeout {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestEncodeReaderLimits(t *testing.T) {
+	long := strings.Repeat("x", readWindow)
+	cases := []struct {
+		msg    string
+		in     string
+		limits metadata.Limits
+		reason string
+	}{
+		{
+			msg:    ".jtop.test.StringReq",
+			in:     `{"str":"` + long + `"}`,
+			limits: metadata.Limits{MaxStringLength: 100},
+			reason: "exceeds max string length 100",
+		},
+		{
+			msg:    ".jtop.test.StringReq",
+			in:     `{"str":"abc"}   `,
+			limits: metadata.Limits{MaxBodyBytes: 14},
+			reason: "exceeds max body bytes 14",
+		},
+		{
+			msg:    ".jtop.test.WellKnownReq",
+			in:     `{"any":{"i32":"` + long + `","@type":"type.googleapis.com/jtop.test.NumberReq"}}`,
+			reason: "look-ahead for @type of google.protobuf.Any exceeds 32768 bytes",
+		},
+	}
+	for _, c := range cases {
+		_, err := Options{Limits: c.limits}.EncodeReader(testdata.TestMessages[c.msg], iotest.OneByteReader(strings.NewReader(c.in)))
+		if e, ok := err.(*Error); !ok || e.Reason != c.reason {
+			t.Fatalf("%s: unexpected error %v", c.reason, err)
+		}
+	}
+	// a long string within the limit grows the window
+	r, err := Options{Limits: metadata.Limits{MaxStringLength: 2 * readWindow}}.EncodeReader(
+		testdata.TestMessages[".jtop.test.StringReq"], iotest.OneByteReader(strings.NewReader(`{"str":"`+long+`"}`)))
+	if err != nil {
+		t.Fatalf("encode error: %s\n", err)
+	}
+	if len(r) != readWindow+4 {
+		t.Fatalf("unexpected length %d", len(r))
+	}
+}
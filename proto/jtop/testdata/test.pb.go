@@ -8,6 +8,11 @@ package testdata
 
 import (
 	proto "github.com/golang/protobuf/proto"
+	any1 "github.com/golang/protobuf/ptypes/any"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	_ "github.com/zhiduoke/gapi/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -26,6 +31,58 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type Status int32
+
+const (
+	Status_UNKNOWN Status = 0
+	Status_ACTIVE  Status = 1
+	Status_BLOCKED Status = 2
+	Status_DELETED Status = -1
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0:  "UNKNOWN",
+		1:  "ACTIVE",
+		2:  "BLOCKED",
+		-1: "DELETED",
+	}
+	Status_value = map[string]int32{
+		"UNKNOWN": 0,
+		"ACTIVE":  1,
+		"BLOCKED": 2,
+		"DELETED": -1,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_test_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_test_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{0}
+}
+
 type Dummy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -562,132 +619,578 @@ func (x *ArrayReq) GetMapObjs() []*MapReq {
 	return nil
 }
 
+type EnumReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   Status            `protobuf:"varint,1,opt,name=status,proto3,enum=jtop.test.Status" json:"status,omitempty"`
+	Statuses []Status          `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=jtop.test.Status" json:"statuses,omitempty"`
+	Sme      map[string]Status `protobuf:"bytes,3,rep,name=sme,proto3" json:"sme,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=jtop.test.Status"`
+}
+
+func (x *EnumReq) Reset() {
+	*x = EnumReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnumReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnumReq) ProtoMessage() {}
+
+func (x *EnumReq) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnumReq.ProtoReflect.Descriptor instead.
+func (*EnumReq) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EnumReq) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_UNKNOWN
+}
+
+func (x *EnumReq) GetStatuses() []Status {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *EnumReq) GetSme() map[string]Status {
+	if x != nil {
+		return x.Sme
+	}
+	return nil
+}
+
+type WellKnownReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ts     *timestamp.Timestamp   `protobuf:"bytes,1,opt,name=ts,proto3" json:"ts,omitempty"`
+	Dur    *duration.Duration     `protobuf:"bytes,2,opt,name=dur,proto3" json:"dur,omitempty"`
+	I64    *wrappers.Int64Value   `protobuf:"bytes,3,opt,name=i64,proto3" json:"i64,omitempty"`
+	Str    *wrappers.StringValue  `protobuf:"bytes,4,opt,name=str,proto3" json:"str,omitempty"`
+	Bool   *wrappers.BoolValue    `protobuf:"bytes,5,opt,name=bool,proto3" json:"bool,omitempty"`
+	St     *_struct.Struct        `protobuf:"bytes,6,opt,name=st,proto3" json:"st,omitempty"`
+	Val    *_struct.Value         `protobuf:"bytes,7,opt,name=val,proto3" json:"val,omitempty"`
+	List   *_struct.ListValue     `protobuf:"bytes,8,opt,name=list,proto3" json:"list,omitempty"`
+	Any    *any1.Any              `protobuf:"bytes,9,opt,name=any,proto3" json:"any,omitempty"`
+	AnyWkt *any1.Any              `protobuf:"bytes,10,opt,name=any_wkt,json=anyWkt,proto3" json:"any_wkt,omitempty"`
+	Tss    []*timestamp.Timestamp `protobuf:"bytes,11,rep,name=tss,proto3" json:"tss,omitempty"`
+}
+
+func (x *WellKnownReq) Reset() {
+	*x = WellKnownReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WellKnownReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WellKnownReq) ProtoMessage() {}
+
+func (x *WellKnownReq) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WellKnownReq.ProtoReflect.Descriptor instead.
+func (*WellKnownReq) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WellKnownReq) GetTs() *timestamp.Timestamp {
+	if x != nil {
+		return x.Ts
+	}
+	return nil
+}
+
+func (x *WellKnownReq) GetDur() *duration.Duration {
+	if x != nil {
+		return x.Dur
+	}
+	return nil
+}
+
+func (x *WellKnownReq) GetI64() *wrappers.Int64Value {
+	if x != nil {
+		return x.I64
+	}
+	return nil
+}
+
+func (x *WellKnownReq) GetStr() *wrappers.StringValue {
+	if x != nil {
+		return x.Str
+	}
+	return nil
+}
+
+func (x *WellKnownReq) GetBool() *wrappers.BoolValue {
+	if x != nil {
+		return x.Bool
+	}
+	return nil
+}
+
+func (x *WellKnownReq) GetSt() *_struct.Struct {
+	if x != nil {
+		return x.St
+	}
+	return nil
+}
+
+func (x *WellKnownReq) GetVal() *_struct.Value {
+	if x != nil {
+		return x.Val
+	}
+	return nil
+}
+
+func (x *WellKnownReq) GetList() *_struct.ListValue {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *WellKnownReq) GetAny() *any1.Any {
+	if x != nil {
+		return x.Any
+	}
+	return nil
+}
+
+func (x *WellKnownReq) GetAnyWkt() *any1.Any {
+	if x != nil {
+		return x.AnyWkt
+	}
+	return nil
+}
+
+func (x *WellKnownReq) GetTss() []*timestamp.Timestamp {
+	if x != nil {
+		return x.Tss
+	}
+	return nil
+}
+
+type OneofReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Contact:
+	//	*OneofReq_Email
+	//	*OneofReq_Phone
+	//	*OneofReq_Num
+	Contact isOneofReq_Contact `protobuf_oneof:"contact"`
+	A       int32              `protobuf:"varint,4,opt,name=a,proto3" json:"a,omitempty"`
+}
+
+func (x *OneofReq) Reset() {
+	*x = OneofReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OneofReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OneofReq) ProtoMessage() {}
+
+func (x *OneofReq) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OneofReq.ProtoReflect.Descriptor instead.
+func (*OneofReq) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{9}
+}
+
+func (m *OneofReq) GetContact() isOneofReq_Contact {
+	if m != nil {
+		return m.Contact
+	}
+	return nil
+}
+
+func (x *OneofReq) GetEmail() string {
+	if x, ok := x.GetContact().(*OneofReq_Email); ok {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *OneofReq) GetPhone() string {
+	if x, ok := x.GetContact().(*OneofReq_Phone); ok {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *OneofReq) GetNum() *NumberReq {
+	if x, ok := x.GetContact().(*OneofReq_Num); ok {
+		return x.Num
+	}
+	return nil
+}
+
+func (x *OneofReq) GetA() int32 {
+	if x != nil {
+		return x.A
+	}
+	return 0
+}
+
+type isOneofReq_Contact interface {
+	isOneofReq_Contact()
+}
+
+type OneofReq_Email struct {
+	Email string `protobuf:"bytes,1,opt,name=email,proto3,oneof"`
+}
+
+type OneofReq_Phone struct {
+	Phone string `protobuf:"bytes,2,opt,name=phone,proto3,oneof"`
+}
+
+type OneofReq_Num struct {
+	Num *NumberReq `protobuf:"bytes,3,opt,name=num,proto3,oneof"`
+}
+
+func (*OneofReq_Email) isOneofReq_Contact() {}
+
+func (*OneofReq_Phone) isOneofReq_Contact() {}
+
+func (*OneofReq_Num) isOneofReq_Contact() {}
+
+type OptionalReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	A *int32  `protobuf:"varint,1,opt,name=a,proto3,oneof" json:"a,omitempty"`
+	B *string `protobuf:"bytes,2,opt,name=b,proto3,oneof" json:"b,omitempty"`
+	C int32   `protobuf:"varint,3,opt,name=c,proto3" json:"c,omitempty"`
+}
+
+func (x *OptionalReq) Reset() {
+	*x = OptionalReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OptionalReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptionalReq) ProtoMessage() {}
+
+func (x *OptionalReq) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptionalReq.ProtoReflect.Descriptor instead.
+func (*OptionalReq) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OptionalReq) GetA() int32 {
+	if x != nil && x.A != nil {
+		return *x.A
+	}
+	return 0
+}
+
+func (x *OptionalReq) GetB() string {
+	if x != nil && x.B != nil {
+		return *x.B
+	}
+	return ""
+}
+
+func (x *OptionalReq) GetC() int32 {
+	if x != nil {
+		return x.C
+	}
+	return 0
+}
+
 var File_test_proto protoreflect.FileDescriptor
 
 var file_test_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x6a, 0x74,
 	0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61,
-	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0x07, 0x0a, 0x05, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x89, 0x02, 0x0a, 0x09, 0x4e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x33, 0x32, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x03, 0x69, 0x33, 0x32, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x36, 0x34, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x69, 0x36, 0x34, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x69,
-	0x33, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x75, 0x69, 0x33, 0x32, 0x12, 0x12,
-	0x0a, 0x04, 0x75, 0x69, 0x36, 0x34, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x75, 0x69,
-	0x36, 0x34, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x33, 0x32, 0x18, 0x05, 0x20, 0x01, 0x28, 0x11,
-	0x52, 0x04, 0x73, 0x69, 0x33, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x36, 0x34, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x12, 0x52, 0x04, 0x73, 0x69, 0x36, 0x34, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c,
-	0x6f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x66, 0x6c, 0x6f, 0x61, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x06, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x78, 0x33,
-	0x32, 0x18, 0x09, 0x20, 0x01, 0x28, 0x07, 0x52, 0x05, 0x66, 0x69, 0x78, 0x33, 0x32, 0x12, 0x14,
-	0x0a, 0x05, 0x66, 0x69, 0x78, 0x36, 0x34, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x06, 0x52, 0x05, 0x66,
-	0x69, 0x78, 0x36, 0x34, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x66, 0x69, 0x78, 0x33, 0x32, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x0f, 0x52, 0x06, 0x73, 0x66, 0x69, 0x78, 0x33, 0x32, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x66, 0x69, 0x78, 0x36, 0x34, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x10, 0x52, 0x06, 0x73, 0x66,
-	0x69, 0x78, 0x36, 0x34, 0x22, 0x33, 0x0a, 0x09, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65,
-	0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x73, 0x74, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x61, 0x65, 0x36, 0x34, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x05, 0x62, 0x61, 0x65, 0x36, 0x34, 0x22, 0x25, 0x0a, 0x07, 0x42, 0x6f, 0x6f,
-	0x6c, 0x52, 0x65, 0x71, 0x12, 0x0c, 0x0a, 0x01, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x01, 0x61, 0x12, 0x0c, 0x0a, 0x01, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x01, 0x62,
-	0x22, 0xc7, 0x01, 0x0a, 0x09, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x12, 0x26,
-	0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x74,
-	0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65,
-	0x71, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x12, 0x26, 0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x52, 0x03, 0x73, 0x74, 0x72, 0x12, 0x26,
-	0x0a, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6a,
-	0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71,
-	0x52, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x12, 0x26, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e,
-	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x12, 0x0c,
-	0x0a, 0x01, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x61, 0x12, 0x0c, 0x0a, 0x01,
-	0x62, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x01, 0x62, 0x22, 0xad, 0x05, 0x0a, 0x06, 0x4d,
-	0x61, 0x70, 0x52, 0x65, 0x71, 0x12, 0x2c, 0x0a, 0x03, 0x73, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d,
-	0x61, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x53, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03,
-	0x73, 0x6d, 0x73, 0x12, 0x2c, 0x0a, 0x03, 0x73, 0x6d, 0x69, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70,
-	0x52, 0x65, 0x71, 0x2e, 0x53, 0x6d, 0x69, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x73, 0x6d,
-	0x69, 0x12, 0x2c, 0x0a, 0x03, 0x62, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65,
-	0x71, 0x2e, 0x42, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x62, 0x6d, 0x73, 0x12,
-	0x2c, 0x0a, 0x03, 0x73, 0x6d, 0x6f, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70,
+	0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07, 0x0a, 0x05, 0x44, 0x75, 0x6d,
+	0x6d, 0x79, 0x22, 0x89, 0x02, 0x0a, 0x09, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x12, 0x10, 0x0a, 0x03, 0x69, 0x33, 0x32, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x69,
+	0x33, 0x32, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x36, 0x34, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x69, 0x36, 0x34, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x69, 0x33, 0x32, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x75, 0x69, 0x33, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x69, 0x36, 0x34,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x75, 0x69, 0x36, 0x34, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x69, 0x33, 0x32, 0x18, 0x05, 0x20, 0x01, 0x28, 0x11, 0x52, 0x04, 0x73, 0x69, 0x33, 0x32,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x36, 0x34, 0x18, 0x06, 0x20, 0x01, 0x28, 0x12, 0x52, 0x04,
+	0x73, 0x69, 0x36, 0x34, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x05, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f,
+	0x75, 0x62, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x64, 0x6f, 0x75, 0x62,
+	0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x78, 0x33, 0x32, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x07, 0x52, 0x05, 0x66, 0x69, 0x78, 0x33, 0x32, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x78, 0x36,
+	0x34, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x06, 0x52, 0x05, 0x66, 0x69, 0x78, 0x36, 0x34, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x66, 0x69, 0x78, 0x33, 0x32, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0f, 0x52, 0x06,
+	0x73, 0x66, 0x69, 0x78, 0x33, 0x32, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x66, 0x69, 0x78, 0x36, 0x34,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x10, 0x52, 0x06, 0x73, 0x66, 0x69, 0x78, 0x36, 0x34, 0x22, 0x33,
+	0x0a, 0x09, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x74, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x74, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x62, 0x61, 0x65, 0x36, 0x34, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x61,
+	0x65, 0x36, 0x34, 0x22, 0x25, 0x0a, 0x07, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x0c,
+	0x0a, 0x01, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x01, 0x61, 0x12, 0x0c, 0x0a, 0x01,
+	0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x01, 0x62, 0x22, 0xc7, 0x01, 0x0a, 0x09, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x52, 0x03, 0x6e, 0x75, 0x6d,
+	0x12, 0x26, 0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x52, 0x03, 0x73, 0x74, 0x72, 0x12, 0x26, 0x0a, 0x04, 0x62, 0x6f, 0x6f, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x52, 0x04, 0x62, 0x6f, 0x6f, 0x6c,
+	0x12, 0x26, 0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x71, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x12, 0x0c, 0x0a, 0x01, 0x61, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x01, 0x61, 0x12, 0x0c, 0x0a, 0x01, 0x62, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x01, 0x62, 0x22, 0xad, 0x05, 0x0a, 0x06, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x12,
+	0x2c, 0x0a, 0x03, 0x73, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a,
 	0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x2e,
-	0x53, 0x6d, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x73, 0x6d, 0x6f, 0x12, 0x2c, 0x0a,
-	0x03, 0x69, 0x6d, 0x6f, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x74, 0x6f,
-	0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x49, 0x6d,
-	0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x69, 0x6d, 0x6f, 0x12, 0x2c, 0x0a, 0x03, 0x73,
-	0x6d, 0x61, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e,
-	0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x53, 0x6d, 0x61, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x73, 0x6d, 0x61, 0x1a, 0x36, 0x0a, 0x08, 0x53, 0x6d, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x1a, 0x36, 0x0a, 0x08, 0x53, 0x6d, 0x69, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x36, 0x0a, 0x08, 0x42, 0x6d, 0x73,
+	0x53, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x73, 0x6d, 0x73, 0x12, 0x2c, 0x0a,
+	0x03, 0x73, 0x6d, 0x69, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x74, 0x6f,
+	0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x53, 0x6d,
+	0x69, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x73, 0x6d, 0x69, 0x12, 0x2c, 0x0a, 0x03, 0x62,
+	0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x42, 0x6d, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x62, 0x6d, 0x73, 0x12, 0x2c, 0x0a, 0x03, 0x73, 0x6d, 0x6f,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x53, 0x6d, 0x6f, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x03, 0x73, 0x6d, 0x6f, 0x12, 0x2c, 0x0a, 0x03, 0x69, 0x6d, 0x6f, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x49, 0x6d, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x03, 0x69, 0x6d, 0x6f, 0x12, 0x2c, 0x0a, 0x03, 0x73, 0x6d, 0x61, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x61, 0x70, 0x52, 0x65, 0x71, 0x2e, 0x53, 0x6d, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03,
+	0x73, 0x6d, 0x61, 0x1a, 0x36, 0x0a, 0x08, 0x53, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x36, 0x0a, 0x08, 0x53,
+	0x6d, 0x69, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x36, 0x0a, 0x08, 0x42, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4c, 0x0a, 0x08, 0x53,
+	0x6d, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4c, 0x0a, 0x08, 0x49, 0x6d, 0x6f,
 	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x1a, 0x4c, 0x0a, 0x08, 0x53, 0x6d, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
-	0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63,
-	0x74, 0x52, 0x65, 0x71, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
-	0x4c, 0x0a, 0x08, 0x49, 0x6d, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a,
-	0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52,
-	0x65, 0x71, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4b, 0x0a,
-	0x08, 0x53, 0x6d, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6a, 0x74, 0x6f,
-	0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x65, 0x71, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9f, 0x01, 0x0a, 0x08, 0x41,
-	0x72, 0x72, 0x61, 0x79, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x75, 0x6d, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x6e, 0x75, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73,
-	0x74, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74, 0x72, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x62, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x08, 0x52, 0x05,
-	0x62, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x6f, 0x62, 0x6a, 0x73, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e,
-	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x52, 0x04, 0x6f, 0x62, 0x6a, 0x73, 0x12,
-	0x2b, 0x0a, 0x07, 0x6d, 0x61, 0x70, 0x4f, 0x62, 0x6a, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70,
-	0x52, 0x65, 0x71, 0x52, 0x07, 0x6d, 0x61, 0x70, 0x4f, 0x62, 0x6a, 0x73, 0x32, 0xd1, 0x03, 0x0a,
-	0x0a, 0x54, 0x65, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0a, 0x54,
-	0x65, 0x73, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70,
-	0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a,
-	0x10, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d,
-	0x79, 0x22, 0x0e, 0xd2, 0xd3, 0xee, 0x0b, 0x09, 0x0a, 0x07, 0x2f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x12, 0x44, 0x0a, 0x0a, 0x54, 0x65, 0x73, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12,
-	0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73,
-	0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0e, 0xd2, 0xd3, 0xee, 0x0b, 0x09, 0x0a, 0x07,
-	0x2f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x3e, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x42,
-	0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e,
-	0x42, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74,
-	0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0c, 0xd2, 0xd3, 0xee, 0x0b, 0x07,
-	0x0a, 0x05, 0x2f, 0x62, 0x6f, 0x6f, 0x6c, 0x12, 0x44, 0x0a, 0x0a, 0x54, 0x65, 0x73, 0x74, 0x4f,
-	0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73,
-	0x74, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74,
-	0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0e, 0xd2,
-	0xd3, 0xee, 0x0b, 0x09, 0x0a, 0x07, 0x2f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x3b, 0x0a,
-	0x07, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x12, 0x11, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e,
-	0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74,
-	0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0b, 0xd2,
-	0xd3, 0xee, 0x0b, 0x06, 0x0a, 0x04, 0x2f, 0x6d, 0x61, 0x70, 0x12, 0x41, 0x0a, 0x09, 0x54, 0x65,
-	0x73, 0x74, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12, 0x13, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74,
-	0x65, 0x73, 0x74, 0x2e, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a,
-	0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0d,
-	0xd2, 0xd3, 0xee, 0x0b, 0x08, 0x0a, 0x06, 0x2f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x1a, 0x31, 0xd2,
-	0xf7, 0xd6, 0x0f, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x68, 0x6f, 0x73, 0x74, 0x3a, 0x31, 0x39,
-	0x30, 0x39, 0x30, 0xe2, 0xf7, 0xd6, 0x0f, 0x08, 0x68, 0x74, 0x74, 0x70, 0x6a, 0x73, 0x6f, 0x6e,
-	0xe8, 0xf7, 0xd6, 0x0f, 0x88, 0x27, 0xf2, 0xf7, 0xd6, 0x0f, 0x05, 0x2f, 0x74, 0x65, 0x73, 0x74,
-	0x42, 0x0a, 0x5a, 0x08, 0x74, 0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4b, 0x0a, 0x08, 0x53, 0x6d, 0x61, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x65, 0x71, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x9f, 0x01, 0x0a, 0x08, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x65,
+	0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x75, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x04, 0x6e, 0x75, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x6f,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x08, 0x52, 0x05, 0x62, 0x6f, 0x6f, 0x6c, 0x73, 0x12,
+	0x28, 0x0a, 0x04, 0x6f, 0x62, 0x6a, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x71, 0x52, 0x04, 0x6f, 0x62, 0x6a, 0x73, 0x12, 0x2b, 0x0a, 0x07, 0x6d, 0x61, 0x70,
+	0x4f, 0x62, 0x6a, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6a, 0x74, 0x6f,
+	0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x52, 0x07, 0x6d,
+	0x61, 0x70, 0x4f, 0x62, 0x6a, 0x73, 0x22, 0xdd, 0x01, 0x0a, 0x07, 0x45, 0x6e, 0x75, 0x6d, 0x52,
+	0x65, 0x71, 0x12, 0x29, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a,
+	0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x11, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x03,
+	0x73, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6a, 0x74, 0x6f, 0x70,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x2e, 0x53, 0x6d,
+	0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x73, 0x6d, 0x65, 0x1a, 0x49, 0x0a, 0x08, 0x53,
+	0x6d, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xfe, 0x03, 0x0a, 0x0c, 0x57, 0x65, 0x6c, 0x6c, 0x4b,
+	0x6e, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x02, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x03, 0x64, 0x75, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x64, 0x75, 0x72,
+	0x12, 0x2d, 0x0a, 0x03, 0x69, 0x36, 0x34, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x69, 0x36, 0x34, 0x12,
+	0x2e, 0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x73, 0x74, 0x72, 0x12,
+	0x2e, 0x0a, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x12,
+	0x27, 0x0a, 0x02, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x02, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x76,
+	0x61, 0x6c, 0x12, 0x2e, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x6c, 0x69,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x03, 0x61, 0x6e, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x03, 0x61, 0x6e, 0x79, 0x12, 0x2d, 0x0a, 0x07, 0x61, 0x6e,
+	0x79, 0x5f, 0x77, 0x6b, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e,
+	0x79, 0x52, 0x06, 0x61, 0x6e, 0x79, 0x57, 0x6b, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x74, 0x73, 0x73,
+	0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x03, 0x74, 0x73, 0x73, 0x22, 0x7d, 0x0a, 0x08, 0x4f, 0x6e, 0x65, 0x6f, 0x66,
+	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x16, 0x0a, 0x05, 0x70,
+	0x68, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x70, 0x68,
+	0x6f, 0x6e, 0x65, 0x12, 0x28, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x12, 0x0c, 0x0a,
+	0x01, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x61, 0x42, 0x09, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x22, 0x4d, 0x0a, 0x0b, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x11, 0x0a, 0x01, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x00, 0x52, 0x01, 0x61, 0x88, 0x01, 0x01, 0x12, 0x11, 0x0a, 0x01, 0x62, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x01, 0x62, 0x88, 0x01, 0x01, 0x12, 0x0c, 0x0a, 0x01, 0x63,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x63, 0x42, 0x04, 0x0a, 0x02, 0x5f, 0x61, 0x42,
+	0x04, 0x0a, 0x02, 0x5f, 0x62, 0x2a, 0x44, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
+	0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x4c, 0x4f, 0x43,
+	0x4b, 0x45, 0x44, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x07, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44,
+	0x10, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x32, 0xf0, 0x05, 0x0a, 0x0a,
+	0x54, 0x65, 0x73, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0a, 0x54, 0x65,
+	0x73, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x10,
+	0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79,
+	0x22, 0x0e, 0xd2, 0xd3, 0xee, 0x0b, 0x09, 0x0a, 0x07, 0x2f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x44, 0x0a, 0x0a, 0x54, 0x65, 0x73, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x14,
+	0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0e, 0xd2, 0xd3, 0xee, 0x0b, 0x09, 0x0a, 0x07, 0x2f,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x3e, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x42, 0x6f,
+	0x6f, 0x6c, 0x12, 0x12, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x42,
+	0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0c, 0xd2, 0xd3, 0xee, 0x0b, 0x07, 0x0a,
+	0x05, 0x2f, 0x62, 0x6f, 0x6f, 0x6c, 0x12, 0x44, 0x0a, 0x0a, 0x54, 0x65, 0x73, 0x74, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x12, 0x14, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74, 0x6f,
+	0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0e, 0xd2, 0xd3,
+	0xee, 0x0b, 0x09, 0x0a, 0x07, 0x2f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x3b, 0x0a, 0x07,
+	0x54, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x12, 0x11, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74, 0x6f,
+	0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0b, 0xd2, 0xd3,
+	0xee, 0x0b, 0x06, 0x0a, 0x04, 0x2f, 0x6d, 0x61, 0x70, 0x12, 0x41, 0x0a, 0x09, 0x54, 0x65, 0x73,
+	0x74, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12, 0x13, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74,
+	0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0d, 0xd2,
+	0xd3, 0xee, 0x0b, 0x08, 0x0a, 0x06, 0x2f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x12, 0x3e, 0x0a, 0x08,
+	0x54, 0x65, 0x73, 0x74, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x12, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a,
+	0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x0c,
+	0xd2, 0xd3, 0xee, 0x0b, 0x07, 0x0a, 0x05, 0x2f, 0x65, 0x6e, 0x75, 0x6d, 0x12, 0x4e, 0x0a, 0x0d,
+	0x54, 0x65, 0x73, 0x74, 0x57, 0x65, 0x6c, 0x6c, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x12, 0x17, 0x2e,
+	0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x57, 0x65, 0x6c, 0x6c, 0x4b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x12, 0xd2, 0xd3, 0xee, 0x0b, 0x0d, 0x0a,
+	0x0b, 0x2f, 0x77, 0x65, 0x6c, 0x6c, 0x5f, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x12, 0x41, 0x0a, 0x09,
+	0x54, 0x65, 0x73, 0x74, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x12, 0x13, 0x2e, 0x6a, 0x74, 0x6f, 0x70,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x1a, 0x10,
+	0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79,
+	0x22, 0x0d, 0xd2, 0xd3, 0xee, 0x0b, 0x08, 0x0a, 0x06, 0x2f, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x12,
+	0x4a, 0x0a, 0x0c, 0x54, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12,
+	0x16, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6a, 0x74, 0x6f, 0x70, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x44, 0x75, 0x6d, 0x6d, 0x79, 0x22, 0x10, 0xd2, 0xd3, 0xee, 0x0b, 0x0b,
+	0x0a, 0x09, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x1a, 0x31, 0xd2, 0xf7, 0xd6,
+	0x0f, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x68, 0x6f, 0x73, 0x74, 0x3a, 0x31, 0x39, 0x30, 0x39,
+	0x30, 0xe2, 0xf7, 0xd6, 0x0f, 0x08, 0x68, 0x74, 0x74, 0x70, 0x6a, 0x73, 0x6f, 0x6e, 0xe8, 0xf7,
+	0xd6, 0x0f, 0x88, 0x27, 0xf2, 0xf7, 0xd6, 0x0f, 0x05, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x42, 0x0a,
+	0x5a, 0x08, 0x74, 0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -702,55 +1205,95 @@ func file_test_proto_rawDescGZIP() []byte {
 	return file_test_proto_rawDescData
 }
 
-var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_test_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_test_proto_goTypes = []interface{}{
-	(*Dummy)(nil),     // 0: jtop.test.Dummy
-	(*NumberReq)(nil), // 1: jtop.test.NumberReq
-	(*StringReq)(nil), // 2: jtop.test.StringReq
-	(*BoolReq)(nil),   // 3: jtop.test.BoolReq
-	(*ObjectReq)(nil), // 4: jtop.test.ObjectReq
-	(*MapReq)(nil),    // 5: jtop.test.MapReq
-	(*ArrayReq)(nil),  // 6: jtop.test.ArrayReq
-	nil,               // 7: jtop.test.MapReq.SmsEntry
-	nil,               // 8: jtop.test.MapReq.SmiEntry
-	nil,               // 9: jtop.test.MapReq.BmsEntry
-	nil,               // 10: jtop.test.MapReq.SmoEntry
-	nil,               // 11: jtop.test.MapReq.ImoEntry
-	nil,               // 12: jtop.test.MapReq.SmaEntry
+	(Status)(0),                  // 0: jtop.test.Status
+	(*Dummy)(nil),                // 1: jtop.test.Dummy
+	(*NumberReq)(nil),            // 2: jtop.test.NumberReq
+	(*StringReq)(nil),            // 3: jtop.test.StringReq
+	(*BoolReq)(nil),              // 4: jtop.test.BoolReq
+	(*ObjectReq)(nil),            // 5: jtop.test.ObjectReq
+	(*MapReq)(nil),               // 6: jtop.test.MapReq
+	(*ArrayReq)(nil),             // 7: jtop.test.ArrayReq
+	(*EnumReq)(nil),              // 8: jtop.test.EnumReq
+	(*WellKnownReq)(nil),         // 9: jtop.test.WellKnownReq
+	(*OneofReq)(nil),             // 10: jtop.test.OneofReq
+	(*OptionalReq)(nil),          // 11: jtop.test.OptionalReq
+	nil,                          // 12: jtop.test.MapReq.SmsEntry
+	nil,                          // 13: jtop.test.MapReq.SmiEntry
+	nil,                          // 14: jtop.test.MapReq.BmsEntry
+	nil,                          // 15: jtop.test.MapReq.SmoEntry
+	nil,                          // 16: jtop.test.MapReq.ImoEntry
+	nil,                          // 17: jtop.test.MapReq.SmaEntry
+	nil,                          // 18: jtop.test.EnumReq.SmeEntry
+	(*timestamp.Timestamp)(nil),  // 19: google.protobuf.Timestamp
+	(*duration.Duration)(nil),    // 20: google.protobuf.Duration
+	(*wrappers.Int64Value)(nil),  // 21: google.protobuf.Int64Value
+	(*wrappers.StringValue)(nil), // 22: google.protobuf.StringValue
+	(*wrappers.BoolValue)(nil),   // 23: google.protobuf.BoolValue
+	(*_struct.Struct)(nil),       // 24: google.protobuf.Struct
+	(*_struct.Value)(nil),        // 25: google.protobuf.Value
+	(*_struct.ListValue)(nil),    // 26: google.protobuf.ListValue
+	(*any1.Any)(nil),             // 27: google.protobuf.Any
 }
 var file_test_proto_depIdxs = []int32{
-	1,  // 0: jtop.test.ObjectReq.num:type_name -> jtop.test.NumberReq
-	2,  // 1: jtop.test.ObjectReq.str:type_name -> jtop.test.StringReq
-	3,  // 2: jtop.test.ObjectReq.bool:type_name -> jtop.test.BoolReq
-	4,  // 3: jtop.test.ObjectReq.obj:type_name -> jtop.test.ObjectReq
-	7,  // 4: jtop.test.MapReq.sms:type_name -> jtop.test.MapReq.SmsEntry
-	8,  // 5: jtop.test.MapReq.smi:type_name -> jtop.test.MapReq.SmiEntry
-	9,  // 6: jtop.test.MapReq.bms:type_name -> jtop.test.MapReq.BmsEntry
-	10, // 7: jtop.test.MapReq.smo:type_name -> jtop.test.MapReq.SmoEntry
-	11, // 8: jtop.test.MapReq.imo:type_name -> jtop.test.MapReq.ImoEntry
-	12, // 9: jtop.test.MapReq.sma:type_name -> jtop.test.MapReq.SmaEntry
-	4,  // 10: jtop.test.ArrayReq.objs:type_name -> jtop.test.ObjectReq
-	5,  // 11: jtop.test.ArrayReq.mapObjs:type_name -> jtop.test.MapReq
-	4,  // 12: jtop.test.MapReq.SmoEntry.value:type_name -> jtop.test.ObjectReq
-	4,  // 13: jtop.test.MapReq.ImoEntry.value:type_name -> jtop.test.ObjectReq
-	6,  // 14: jtop.test.MapReq.SmaEntry.value:type_name -> jtop.test.ArrayReq
-	1,  // 15: jtop.test.TestServer.TestNumber:input_type -> jtop.test.NumberReq
-	2,  // 16: jtop.test.TestServer.TestString:input_type -> jtop.test.StringReq
-	3,  // 17: jtop.test.TestServer.TestBool:input_type -> jtop.test.BoolReq
-	4,  // 18: jtop.test.TestServer.TestObject:input_type -> jtop.test.ObjectReq
-	5,  // 19: jtop.test.TestServer.TestMap:input_type -> jtop.test.MapReq
-	6,  // 20: jtop.test.TestServer.TestArray:input_type -> jtop.test.ArrayReq
-	0,  // 21: jtop.test.TestServer.TestNumber:output_type -> jtop.test.Dummy
-	0,  // 22: jtop.test.TestServer.TestString:output_type -> jtop.test.Dummy
-	0,  // 23: jtop.test.TestServer.TestBool:output_type -> jtop.test.Dummy
-	0,  // 24: jtop.test.TestServer.TestObject:output_type -> jtop.test.Dummy
-	0,  // 25: jtop.test.TestServer.TestMap:output_type -> jtop.test.Dummy
-	0,  // 26: jtop.test.TestServer.TestArray:output_type -> jtop.test.Dummy
-	21, // [21:27] is the sub-list for method output_type
-	15, // [15:21] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	2,  // 0: jtop.test.ObjectReq.num:type_name -> jtop.test.NumberReq
+	3,  // 1: jtop.test.ObjectReq.str:type_name -> jtop.test.StringReq
+	4,  // 2: jtop.test.ObjectReq.bool:type_name -> jtop.test.BoolReq
+	5,  // 3: jtop.test.ObjectReq.obj:type_name -> jtop.test.ObjectReq
+	12, // 4: jtop.test.MapReq.sms:type_name -> jtop.test.MapReq.SmsEntry
+	13, // 5: jtop.test.MapReq.smi:type_name -> jtop.test.MapReq.SmiEntry
+	14, // 6: jtop.test.MapReq.bms:type_name -> jtop.test.MapReq.BmsEntry
+	15, // 7: jtop.test.MapReq.smo:type_name -> jtop.test.MapReq.SmoEntry
+	16, // 8: jtop.test.MapReq.imo:type_name -> jtop.test.MapReq.ImoEntry
+	17, // 9: jtop.test.MapReq.sma:type_name -> jtop.test.MapReq.SmaEntry
+	5,  // 10: jtop.test.ArrayReq.objs:type_name -> jtop.test.ObjectReq
+	6,  // 11: jtop.test.ArrayReq.mapObjs:type_name -> jtop.test.MapReq
+	0,  // 12: jtop.test.EnumReq.status:type_name -> jtop.test.Status
+	0,  // 13: jtop.test.EnumReq.statuses:type_name -> jtop.test.Status
+	18, // 14: jtop.test.EnumReq.sme:type_name -> jtop.test.EnumReq.SmeEntry
+	19, // 15: jtop.test.WellKnownReq.ts:type_name -> google.protobuf.Timestamp
+	20, // 16: jtop.test.WellKnownReq.dur:type_name -> google.protobuf.Duration
+	21, // 17: jtop.test.WellKnownReq.i64:type_name -> google.protobuf.Int64Value
+	22, // 18: jtop.test.WellKnownReq.str:type_name -> google.protobuf.StringValue
+	23, // 19: jtop.test.WellKnownReq.bool:type_name -> google.protobuf.BoolValue
+	24, // 20: jtop.test.WellKnownReq.st:type_name -> google.protobuf.Struct
+	25, // 21: jtop.test.WellKnownReq.val:type_name -> google.protobuf.Value
+	26, // 22: jtop.test.WellKnownReq.list:type_name -> google.protobuf.ListValue
+	27, // 23: jtop.test.WellKnownReq.any:type_name -> google.protobuf.Any
+	27, // 24: jtop.test.WellKnownReq.any_wkt:type_name -> google.protobuf.Any
+	19, // 25: jtop.test.WellKnownReq.tss:type_name -> google.protobuf.Timestamp
+	2,  // 26: jtop.test.OneofReq.num:type_name -> jtop.test.NumberReq
+	5,  // 27: jtop.test.MapReq.SmoEntry.value:type_name -> jtop.test.ObjectReq
+	5,  // 28: jtop.test.MapReq.ImoEntry.value:type_name -> jtop.test.ObjectReq
+	7,  // 29: jtop.test.MapReq.SmaEntry.value:type_name -> jtop.test.ArrayReq
+	0,  // 30: jtop.test.EnumReq.SmeEntry.value:type_name -> jtop.test.Status
+	2,  // 31: jtop.test.TestServer.TestNumber:input_type -> jtop.test.NumberReq
+	3,  // 32: jtop.test.TestServer.TestString:input_type -> jtop.test.StringReq
+	4,  // 33: jtop.test.TestServer.TestBool:input_type -> jtop.test.BoolReq
+	5,  // 34: jtop.test.TestServer.TestObject:input_type -> jtop.test.ObjectReq
+	6,  // 35: jtop.test.TestServer.TestMap:input_type -> jtop.test.MapReq
+	7,  // 36: jtop.test.TestServer.TestArray:input_type -> jtop.test.ArrayReq
+	8,  // 37: jtop.test.TestServer.TestEnum:input_type -> jtop.test.EnumReq
+	9,  // 38: jtop.test.TestServer.TestWellKnown:input_type -> jtop.test.WellKnownReq
+	10, // 39: jtop.test.TestServer.TestOneof:input_type -> jtop.test.OneofReq
+	11, // 40: jtop.test.TestServer.TestOptional:input_type -> jtop.test.OptionalReq
+	1,  // 41: jtop.test.TestServer.TestNumber:output_type -> jtop.test.Dummy
+	1,  // 42: jtop.test.TestServer.TestString:output_type -> jtop.test.Dummy
+	1,  // 43: jtop.test.TestServer.TestBool:output_type -> jtop.test.Dummy
+	1,  // 44: jtop.test.TestServer.TestObject:output_type -> jtop.test.Dummy
+	1,  // 45: jtop.test.TestServer.TestMap:output_type -> jtop.test.Dummy
+	1,  // 46: jtop.test.TestServer.TestArray:output_type -> jtop.test.Dummy
+	1,  // 47: jtop.test.TestServer.TestEnum:output_type -> jtop.test.Dummy
+	1,  // 48: jtop.test.TestServer.TestWellKnown:output_type -> jtop.test.Dummy
+	1,  // 49: jtop.test.TestServer.TestOneof:output_type -> jtop.test.Dummy
+	1,  // 50: jtop.test.TestServer.TestOptional:output_type -> jtop.test.Dummy
+	41, // [41:51] is the sub-list for method output_type
+	31, // [31:41] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_test_proto_init() }
@@ -843,19 +1386,74 @@ func file_test_proto_init() {
 				return nil
 			}
 		}
+		file_test_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnumReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WellKnownReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OneofReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OptionalReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_test_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*OneofReq_Email)(nil),
+		(*OneofReq_Phone)(nil),
+		(*OneofReq_Num)(nil),
 	}
+	file_test_proto_msgTypes[10].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_test_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      1,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_test_proto_goTypes,
 		DependencyIndexes: file_test_proto_depIdxs,
+		EnumInfos:         file_test_proto_enumTypes,
 		MessageInfos:      file_test_proto_msgTypes,
 	}.Build()
 	File_test_proto = out.File
@@ -0,0 +1,442 @@
+package jtop
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// https://developers.google.com/protocol-buffers/docs/proto3#json
+
+func (e *Encoder) transWellKnown(token *Token, field *metadata.Field) {
+	b := e.encodeWellKnown(token, field.Message)
+	if e.err != nil {
+		if err, ok := e.err.(*Error); ok && err.Kind == metadata.InvalidType {
+			err.Kind = field.Kind
+		}
+		return
+	}
+	if field == e.rootField && e.rootField.Message != nil {
+		e.rootField.Message = nil
+		e.buf.SetBuf(append(e.buf.Bytes(), b...))
+		return
+	}
+	if token.Kind == Null && field.Message.WellKnown != metadata.ValueType {
+		// null means not set
+		return
+	}
+	e.encodeBytes(field.Tag, b)
+}
+
+func (e *Encoder) encodeWellKnown(token *Token, msg *metadata.Message) []byte {
+	t := msg.WellKnown
+	if token.Kind == Null && t != metadata.ValueType {
+		return nil
+	}
+	switch {
+	case t == metadata.TimestampType:
+		return e.appendTimestamp(nil, token, msg)
+	case t == metadata.DurationType:
+		return e.appendDuration(nil, token, msg)
+	case t.IsWrapper():
+		return e.appendWrapper(nil, token, msg)
+	case t == metadata.StructType:
+		if token.Kind != ObjectBegin {
+			e.setErrorWellKnown(token, msg)
+			return nil
+		}
+		return e.appendStruct(nil, token)
+	case t == metadata.ValueType:
+		return e.appendStructValue(nil, token)
+	case t == metadata.ListValueType:
+		if token.Kind != ArrayBegin {
+			e.setErrorWellKnown(token, msg)
+			return nil
+		}
+		return e.appendListValue(nil, token)
+	case t == metadata.AnyType:
+		if token.Kind != ObjectBegin {
+			e.setErrorWellKnown(token, msg)
+			return nil
+		}
+		return e.appendAny(nil, msg)
+	default:
+		e.err = fmt.Errorf("unsupported well-known type: %s", msg.Name)
+		return nil
+	}
+}
+
+func (e *Encoder) setErrorWellKnown(token *Token, msg *metadata.Message) {
+	e.fail(token, nil, "invalid value %s of %s", token.Value, strings.TrimPrefix(msg.Name, "."))
+}
+
+func (e *Encoder) unquoteWellKnown(token *Token, msg *metadata.Message) (string, bool) {
+	if token.Kind != String {
+		e.setErrorWellKnown(token, msg)
+		return "", false
+	}
+	s, ok := e.unquoteString(token.Value)
+	if !ok {
+		e.setErrorInvalidJsonToken(token, nil, errors.New("invalid string format"))
+		return "", false
+	}
+	return *(*string)(unsafe.Pointer(&s)), true
+}
+
+func appendSecondsAndNanos(b []byte, seconds int64, nanos int32) []byte {
+	if seconds != 0 {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(seconds))
+	}
+	if nanos != 0 {
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(int64(nanos)))
+	}
+	return b
+}
+
+func (e *Encoder) appendTimestamp(b []byte, token *Token, msg *metadata.Message) []byte {
+	s, ok := e.unquoteWellKnown(token, msg)
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		e.setErrorInvalidJsonToken(token, nil, err)
+		return nil
+	}
+	return appendSecondsAndNanos(b, t.Unix(), int32(t.Nanosecond()))
+}
+
+func (e *Encoder) appendDuration(b []byte, token *Token, msg *metadata.Message) []byte {
+	s, ok := e.unquoteWellKnown(token, msg)
+	if !ok {
+		return nil
+	}
+	seconds, nanos, err := parseDuration(s)
+	if err != nil {
+		e.setErrorInvalidJsonToken(token, nil, err)
+		return nil
+	}
+	return appendSecondsAndNanos(b, seconds, nanos)
+}
+
+// parseDuration parses durations like "1.5s", "-0.000001s".
+func parseDuration(s string) (int64, int32, error) {
+	if len(s) < 2 || s[len(s)-1] != 's' {
+		return 0, 0, errors.New("duration must end with 's'")
+	}
+	s = s[:len(s)-1]
+	neg := false
+	if s[0] == '-' {
+		neg = true
+		s = s[1:]
+	}
+	frac := ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s, frac = s[:i], s[i+1:]
+	}
+	if s == "" || s[0] == '+' || len(frac) > 9 {
+		return 0, 0, errors.New("invalid duration")
+	}
+	seconds, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	var nanos int64
+	if frac != "" {
+		nanos, err = strconv.ParseInt(frac, 10, 32)
+		if err != nil || frac[0] == '-' || frac[0] == '+' {
+			return 0, 0, errors.New("invalid duration")
+		}
+		for i := len(frac); i < 9; i++ {
+			nanos *= 10
+		}
+	}
+	if neg {
+		seconds, nanos = -seconds, -nanos
+	}
+	return seconds, int32(nanos), nil
+}
+
+func (e *Encoder) appendWrapper(b []byte, token *Token, msg *metadata.Message) []byte {
+	if len(msg.Fields) == 0 {
+		e.err = errors.New("invalid metadata: wrapper type error")
+		return nil
+	}
+	sub := newEncoder()
+	sub.reset(e.iter, e.st)
+	sub.transToken(token, msg.Fields[0])
+	if sub.err != nil {
+		e.err = sub.err
+	} else {
+		b = append(b, sub.buf.Bytes()...)
+	}
+	putEncoder(sub)
+	return b
+}
+
+func (e *Encoder) appendStruct(b []byte, token *Token) []byte {
+	if !e.checkDepth(token) {
+		return nil
+	}
+	entries := 0
+	for e.iter.Next() {
+		tk := e.iter.Consume()
+		if tk.Kind == ObjectEnd {
+			return b
+		}
+		if tk.Kind == Comma {
+			continue
+		}
+		if tk.Kind != String {
+			e.setErrorInvalidJsonToken(tk, nil, errors.New("unexpected key"))
+			return nil
+		}
+		key, ok := e.unquoteString(tk.Value)
+		if !ok {
+			e.setErrorInvalidJsonToken(tk, nil, errors.New("invalid string format"))
+			return nil
+		}
+		entries++
+		if !e.checkMapEntries(tk, entries) {
+			return nil
+		}
+		var entry []byte
+		entry = protowire.AppendTag(entry, 1, protowire.BytesType)
+		entry = protowire.AppendBytes(entry, key)
+		e.st.pushKey(string(key))
+		e.ignoreToken()
+		if !e.iter.Next() {
+			break
+		}
+		value := e.appendStructValue(nil, e.iter.Consume())
+		if e.err != nil {
+			return nil
+		}
+		e.st.pop()
+		entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+		entry = protowire.AppendBytes(entry, value)
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+	if e.err == nil {
+		e.setErrorInvalidJsonFormat(io.ErrUnexpectedEOF)
+	}
+	return nil
+}
+
+func (e *Encoder) appendStructValue(b []byte, token *Token) []byte {
+	switch token.Kind {
+	case Null:
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, 0)
+	case Number:
+		sval := *(*string)(unsafe.Pointer(&token.Value))
+		fv, err := strconv.ParseFloat(sval, 64)
+		if err != nil {
+			e.setErrorInvalidJsonToken(token, nil, err)
+			return nil
+		}
+		b = protowire.AppendTag(b, 2, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(fv))
+	case String:
+		if !e.checkString(token) {
+			return nil
+		}
+		s, ok := e.unquoteString(token.Value)
+		if !ok {
+			e.setErrorInvalidJsonToken(token, nil, errors.New("invalid string format"))
+			return nil
+		}
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, s)
+	case True, False:
+		b = protowire.AppendTag(b, 4, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(token.Kind == True))
+	case ObjectBegin:
+		v := e.appendStruct(nil, token)
+		if e.err != nil {
+			return nil
+		}
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendBytes(b, v)
+	case ArrayBegin:
+		v := e.appendListValue(nil, token)
+		if e.err != nil {
+			return nil
+		}
+		b = protowire.AppendTag(b, 6, protowire.BytesType)
+		b = protowire.AppendBytes(b, v)
+	default:
+		e.setErrorInvalidJsonToken(token, nil, nil)
+		return nil
+	}
+	return b
+}
+
+func (e *Encoder) appendListValue(b []byte, token *Token) []byte {
+	if !e.checkDepth(token) {
+		return nil
+	}
+	index := 0
+	for e.iter.Next() {
+		tk := e.iter.Consume()
+		if tk.Kind == ArrayEnd {
+			return b
+		}
+		if tk.Kind == Comma {
+			continue
+		}
+		if !e.checkArrayLength(tk, index+1) {
+			return nil
+		}
+		e.st.pushIndex(index)
+		index++
+		v := e.appendStructValue(nil, tk)
+		if e.err != nil {
+			return nil
+		}
+		e.st.pop()
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, v)
+	}
+	if e.err == nil {
+		e.setErrorInvalidJsonFormat(io.ErrUnexpectedEOF)
+	}
+	return nil
+}
+
+// findAnyType looks ahead for "@type" in current object without consuming tokens.
+func (e *Encoder) findAnyType() ([]byte, bool) {
+	mark := e.iter.mark()
+	defer e.iter.rewind(mark)
+	for e.iter.Next() {
+		tk := e.iter.Consume()
+		if tk.Kind == Comma {
+			continue
+		}
+		if tk.Kind != String {
+			return nil, false
+		}
+		key, ok := e.unquoteString(tk.Value)
+		if !ok {
+			return nil, false
+		}
+		isType := string(key) == "@type"
+		e.ignoreToken()
+		if !isType {
+			e.ignoreValueTokens()
+			continue
+		}
+		if !e.iter.Next() {
+			return nil, false
+		}
+		tk = e.iter.Consume()
+		if tk.Kind != String {
+			return nil, false
+		}
+		v, ok := e.unquoteString(tk.Value)
+		return append([]byte(nil), v...), ok
+	}
+	return nil, false
+}
+
+func (e *Encoder) appendAny(b []byte, msg *metadata.Message) []byte {
+	typeURL, ok := e.findAnyType()
+	if !ok {
+		e.fail(nil, nil, "missing @type of google.protobuf.Any")
+		return nil
+	}
+	url := string(typeURL)
+	var packed *metadata.Message
+	if msg.Resolver != nil {
+		packed = msg.Resolver.FindMessage(url[strings.LastIndexByte(url, '/')+1:])
+	}
+	if packed == nil {
+		e.fail(nil, nil, "unknown type of google.protobuf.Any: %s", url)
+		return nil
+	}
+	var value []byte
+	if packed.WellKnown != metadata.NotWellKnown {
+		value = e.appendAnyWellKnown(packed)
+	} else {
+		sub := newEncoder()
+		sub.reset(e.iter, e.st)
+		sub.inAny = true
+		sub.rootField.Message = packed
+		sub.transObject(nil, sub.rootField)
+		if sub.err != nil {
+			e.err = sub.err
+		} else {
+			value = append(value, sub.buf.Bytes()...)
+		}
+		putEncoder(sub)
+	}
+	if e.err != nil {
+		return nil
+	}
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, typeURL)
+	if len(value) > 0 {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, value)
+	}
+	return b
+}
+
+// appendAnyWellKnown reads {"@type": "...", "value": ...}
+func (e *Encoder) appendAnyWellKnown(packed *metadata.Message) []byte {
+	var value []byte
+	for e.iter.Next() {
+		tk := e.iter.Consume()
+		if tk.Kind == ObjectEnd {
+			return value
+		}
+		if tk.Kind == Comma {
+			continue
+		}
+		if tk.Kind != String {
+			e.setErrorInvalidJsonToken(tk, nil, errors.New("unexpected key"))
+			return nil
+		}
+		key, ok := e.unquoteString(tk.Value)
+		if !ok {
+			e.setErrorInvalidJsonToken(tk, nil, errors.New("invalid string format"))
+			return nil
+		}
+		name := string(key)
+		keyToken := *tk
+		e.ignoreToken()
+		if name != "value" {
+			if e.st.opts.Strict && name != "@type" {
+				e.st.pushKey(name)
+				e.fail(&keyToken, nil, "unknown field of google.protobuf.Any")
+				return nil
+			}
+			e.ignoreValueTokens()
+			continue
+		}
+		if !e.iter.Next() {
+			break
+		}
+		e.st.pushKey("value")
+		value = e.encodeWellKnown(e.iter.Consume(), packed)
+		if e.err != nil {
+			return nil
+		}
+		e.st.pop()
+	}
+	if e.err == nil {
+		e.setErrorInvalidJsonFormat(io.ErrUnexpectedEOF)
+	}
+	return nil
+}
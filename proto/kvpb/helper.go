@@ -18,6 +18,7 @@ var numericKinds = [...]bool{
 	metadata.Sfixed32Kind: true,
 	metadata.Sfixed64Kind: true,
 	metadata.BoolKind:     true,
+	metadata.EnumKind:     true,
 }
 
 func isNumeric(kind metadata.TypeKind) bool {
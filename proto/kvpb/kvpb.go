@@ -74,6 +74,10 @@ func (e *Encoder) parseKV(msg *metadata.Message, kv KV) {
 		fn := getters[annotation.FIELD_BIND(field.Options.Bind)]
 
 		fv, ok := fn(field.Name)
+		if ok && len(fv) == 0 && !field.Optional {
+			// empty value only makes sense for fields with explicit presence
+			ok = false
+		}
 		switch {
 		case !ok && !field.Options.Validate:
 			continue
@@ -102,6 +106,10 @@ func (e *Encoder) parseKV(msg *metadata.Message, kv KV) {
 }
 
 func (e *Encoder) transNumber(value string, field *metadata.Field) {
+	// numbers may be quoted like 64-bit integers in JSON
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		value = value[1 : len(value)-1]
+	}
 	wire := protowire.VarintType
 	var (
 		pv  uint64
@@ -122,6 +130,16 @@ func (e *Encoder) transNumber(value string, field *metadata.Field) {
 		var fv int64
 		fv, err = strconv.ParseInt(value, 10, 32)
 		pv = uint64(fv)
+	case metadata.EnumKind:
+		var fv int64
+		fv, err = strconv.ParseInt(value, 10, 32)
+		if err != nil && field.Enum != nil {
+			// accept the value name as well
+			if v := field.Enum.GetValue(value); v != nil {
+				fv, err = int64(v.Number), nil
+			}
+		}
+		pv = uint64(fv)
 	case metadata.Int64Kind:
 		var fv int64
 		fv, err = strconv.ParseInt(value, 10, 64)
@@ -197,3 +215,24 @@ func (e *Encoder) encodeBytes(tag int, v []byte) {
 	e.buf.EncodeVarint(k)
 	e.buf.EncodeRawBytes(v)
 }
+
+// EncodeField encodes the value of a scalar field, which is parsed the same
+// way as Encode.
+func EncodeField(field *metadata.Field, value string) ([]byte, error) {
+	enc := newEncoder()
+	defer putEncoder(enc)
+	enc.reset()
+	switch {
+	case field.Repeated || field.Kind == metadata.MessageKind || field.Kind == metadata.MapKind:
+		return nil, fmt.Errorf("field %s isn't scalar", field.Name)
+	case field.Kind == metadata.StringKind || field.Kind == metadata.BytesKind:
+		enc.transString(value, field)
+	default:
+		enc.transNumber(value, field)
+	}
+	// invalid numbers are skipped by transNumber
+	if enc.err != nil || len(enc.buf.Bytes()) == 0 {
+		return nil, fmt.Errorf("invalid value of field %s: %q", field.Name, value)
+	}
+	return append([]byte(nil), enc.buf.Bytes()...), nil
+}
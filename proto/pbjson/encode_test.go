@@ -2,11 +2,13 @@ package pbjson
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/zhiduoke/gapi/metadata"
 	"github.com/zhiduoke/gapi/tests/msgs"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 func TestEncode(t *testing.T) {
@@ -55,3 +57,407 @@ func TestEncode(t *testing.T) {
 	}
 	t.Log(string(e.Bytes()))
 }
+
+func TestEncodeEnum(t *testing.T) {
+	enum := &metadata.Enum{
+		Name: "status",
+		Values: []*metadata.EnumValue{
+			{Name: "UNKNOWN", Number: 0},
+			{Name: "ACTIVE", Number: 1},
+			{Name: "DELETED", Number: -1},
+		},
+	}
+	enum.BakeValueIndex()
+	msgmd := &metadata.Message{
+		Name: "testmsg",
+		Fields: []*metadata.Field{
+			{
+				Tag:     1,
+				Name:    "a",
+				Kind:    metadata.EnumKind,
+				Enum:    enum,
+				Options: metadata.FieldOptions{EnumAsString: true},
+			},
+			{
+				Tag:      2,
+				Name:     "b",
+				Kind:     metadata.EnumKind,
+				Enum:     enum,
+				Repeated: true,
+				Options:  metadata.FieldOptions{EnumAsString: true},
+			},
+			{
+				Tag:  3,
+				Name: "c",
+				Kind: metadata.EnumKind,
+				Enum: enum,
+			},
+			{
+				Tag:     4,
+				Name:    "d",
+				Kind:    metadata.EnumKind,
+				Enum:    enum,
+				Options: metadata.FieldOptions{EnumAsString: true},
+			},
+		},
+	}
+	msgmd.BakeTagIndex()
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, 1)
+	var packed []byte
+	packed = protowire.AppendVarint(packed, math.MaxUint64) // -1
+	packed = protowire.AppendVarint(packed, 7)
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendBytes(b, packed)
+	b = protowire.AppendTag(b, 3, protowire.VarintType)
+	b = protowire.AppendVarint(b, 1)
+	const expect = `{"a":"ACTIVE","b":["DELETED",7],"c":1,"d":"UNKNOWN"}`
+	e := NewEncoder(nil)
+	e.EncodeMessage(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != expect {
+		t.Fatalf("got %s, want %s", e.Bytes(), expect)
+	}
+	e.Reset()
+	e.EncodeMessageFast(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != expect {
+		t.Fatalf("fast: got %s, want %s", e.Bytes(), expect)
+	}
+}
+
+func TestEncodeOneof(t *testing.T) {
+	oneof := &metadata.Oneof{Name: "contact"}
+	msgmd := &metadata.Message{
+		Name: "testmsg",
+		Fields: []*metadata.Field{
+			{Tag: 1, Name: "email", Kind: metadata.StringKind, Oneof: oneof},
+			{Tag: 2, Name: "phone", Kind: metadata.StringKind, Oneof: oneof},
+			{Tag: 3, Name: "a", Kind: metadata.Int32Kind},
+		},
+	}
+	oneof.Fields = msgmd.Fields[:2]
+	msgmd.Oneofs = []*metadata.Oneof{oneof}
+	msgmd.BakeTagIndex()
+
+	var b []byte
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendString(b, "123")
+	const expect = `{"phone":"123","a":0}`
+	e := NewEncoder(nil)
+	e.EncodeMessage(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != expect {
+		t.Fatalf("got %s, want %s", e.Bytes(), expect)
+	}
+	e.Reset()
+	e.EncodeMessageFast(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != expect {
+		t.Fatalf("fast: got %s, want %s", e.Bytes(), expect)
+	}
+
+	// the last one wins
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendString(b, "a@b.c")
+	e.Reset()
+	e.EncodeMessage(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != `{"email":"a@b.c","a":0}` {
+		t.Fatalf("got %s", e.Bytes())
+	}
+	e.Reset()
+	e.EncodeMessageFast(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != `{"email":"a@b.c","a":0}` {
+		t.Fatalf("fast: got %s", e.Bytes())
+	}
+}
+
+func TestEncodeOptional(t *testing.T) {
+	msgmd := &metadata.Message{
+		Name: "testmsg",
+		Fields: []*metadata.Field{
+			{Tag: 1, Name: "a", Kind: metadata.Int32Kind, Optional: true},
+			{Tag: 2, Name: "b", Kind: metadata.StringKind, Optional: true},
+			{Tag: 3, Name: "c", Kind: metadata.Int32Kind},
+		},
+	}
+	msgmd.BakeTagIndex()
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, 0)
+	const expect = `{"a":0,"c":0}`
+	e := NewEncoder(nil)
+	e.EncodeMessage(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != expect {
+		t.Fatalf("got %s, want %s", e.Bytes(), expect)
+	}
+	e.Reset()
+	e.EncodeMessageFast(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != expect {
+		t.Fatalf("fast: got %s, want %s", e.Bytes(), expect)
+	}
+}
+
+type testResolver map[string]*metadata.Message
+
+func (r testResolver) FindMessage(name string) *metadata.Message {
+	return r[name]
+}
+
+func TestEncodeWellKnown(t *testing.T) {
+	wrapper := &metadata.Message{
+		Name:      ".google.protobuf.Int64Value",
+		Fields:    []*metadata.Field{{Tag: 1, Name: "value", Kind: metadata.Int64Kind}},
+		WellKnown: metadata.Int64ValueType,
+	}
+	wrapper.BakeTagIndex()
+	duration := &metadata.Message{Name: ".google.protobuf.Duration", WellKnown: metadata.DurationType}
+	packed := &metadata.Message{
+		Name:   ".test.Packed",
+		Fields: []*metadata.Field{{Tag: 1, Name: "a", Kind: metadata.Int32Kind}},
+	}
+	packed.BakeTagIndex()
+	msgmd := &metadata.Message{
+		Name: "testmsg",
+		Fields: []*metadata.Field{
+			{Tag: 1, Name: "ts", Kind: metadata.MessageKind, Message: &metadata.Message{WellKnown: metadata.TimestampType}},
+			{Tag: 2, Name: "dur", Kind: metadata.MessageKind, Message: duration},
+			{Tag: 3, Name: "i64", Kind: metadata.MessageKind, Message: wrapper},
+			{Tag: 5, Name: "st", Kind: metadata.MessageKind, Message: &metadata.Message{WellKnown: metadata.StructType}},
+			{Tag: 6, Name: "any", Kind: metadata.MessageKind, Message: &metadata.Message{
+				WellKnown: metadata.AnyType,
+				Resolver: testResolver{
+					"test.Packed":              packed,
+					"google.protobuf.Duration": duration,
+				},
+			}},
+			{Tag: 7, Name: "any_wkt", Kind: metadata.MessageKind, Message: &metadata.Message{
+				WellKnown: metadata.AnyType,
+				Resolver:  testResolver{"google.protobuf.Duration": duration},
+			}},
+			{Tag: 8, Name: "unset", Kind: metadata.MessageKind, Message: wrapper},
+		},
+	}
+	msgmd.BakeTagIndex()
+
+	appendMessage := func(b []byte, tag protowire.Number, m []byte) []byte {
+		b = protowire.AppendTag(b, tag, protowire.BytesType)
+		return protowire.AppendBytes(b, m)
+	}
+	appendVarint := func(b []byte, tag protowire.Number, x uint64) []byte {
+		b = protowire.AppendTag(b, tag, protowire.VarintType)
+		return protowire.AppendVarint(b, x)
+	}
+	var b []byte
+	b = appendMessage(b, 1, appendVarint(appendVarint(nil, 1, 1600000000), 2, 500000000))
+	b = appendMessage(b, 2, appendVarint(appendVarint(nil, 1, math.MaxUint64), 2, uint64(math.MaxUint64-499)))
+	b = appendMessage(b, 3, appendVarint(nil, 1, 64))
+	// {"a":"x","b":[true,null]}
+	var list, st []byte
+	list = appendMessage(list, 1, appendVarint(nil, 4, 1))
+	list = appendMessage(list, 1, appendVarint(nil, 1, 0))
+	st = appendMessage(st, 1, appendMessage(protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), "a"), 2,
+		protowire.AppendString(protowire.AppendTag(nil, 3, protowire.BytesType), "x")))
+	st = appendMessage(st, 1, appendMessage(protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), "b"), 2,
+		appendMessage(nil, 6, list)))
+	b = appendMessage(b, 5, st)
+	anyMsg := protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), "type.googleapis.com/test.Packed")
+	anyMsg = appendMessage(anyMsg, 2, appendVarint(nil, 1, 5))
+	b = appendMessage(b, 6, anyMsg)
+	anyWkt := protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), "type.googleapis.com/google.protobuf.Duration")
+	anyWkt = appendMessage(anyWkt, 2, appendVarint(nil, 1, 3))
+	b = appendMessage(b, 7, anyWkt)
+
+	const expect = `{"ts":"2020-09-13T12:26:40.500Z","dur":"-1.000000500s","i64":64,` +
+		`"st":{"a":"x","b":[true,null]},"any":{"@type":"type.googleapis.com/test.Packed","a":5},` +
+		`"any_wkt":{"@type":"type.googleapis.com/google.protobuf.Duration","value":"3s"},"unset":null}`
+	e := NewEncoder(nil)
+	e.EncodeMessage(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != expect {
+		t.Fatalf("got %s, want %s", e.Bytes(), expect)
+	}
+	e.Reset()
+	e.EncodeMessageFast(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != expect {
+		t.Fatalf("fast: got %s, want %s", e.Bytes(), expect)
+	}
+}
+
+func TestEncodeNaming(t *testing.T) {
+	msgmd := &metadata.Message{
+		Name: "testmsg",
+		Fields: []*metadata.Field{
+			{Tag: 1, Name: "user_id", JSONName: "uid", CamelName: "userId", Kind: metadata.Int32Kind},
+			{Tag: 2, Name: "name", Kind: metadata.StringKind},
+		},
+	}
+	msgmd.BakeTagIndex()
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, 1)
+	for naming, expect := range map[metadata.Naming]string{
+		metadata.OriginalNaming:  `{"user_id":1,"name":""}`,
+		metadata.JSONNaming:      `{"uid":1,"name":""}`,
+		metadata.CamelCaseNaming: `{"userId":1,"name":""}`,
+	} {
+		e := NewEncoder(nil)
+		e.Naming = naming
+		e.EncodeMessage(msgmd, b)
+		if e.Error() != nil {
+			t.Fatal(e.Error())
+		}
+		if string(e.Bytes()) != expect {
+			t.Fatalf("got %s, want %s", e.Bytes(), expect)
+		}
+		e.Reset()
+		e.EncodeMessageFast(msgmd, b)
+		if e.Error() != nil {
+			t.Fatal(e.Error())
+		}
+		if string(e.Bytes()) != expect {
+			t.Fatalf("fast: got %s, want %s", e.Bytes(), expect)
+		}
+	}
+}
+
+func TestEncodeInt64AsString(t *testing.T) {
+	msgmd := &metadata.Message{
+		Name: "testmsg",
+		Fields: []*metadata.Field{
+			{Tag: 1, Name: "a", Kind: metadata.Int64Kind, Options: metadata.FieldOptions{Int64AsString: true}},
+			{Tag: 2, Name: "c", Kind: metadata.Sint64Kind, Repeated: true, Options: metadata.FieldOptions{Int64AsString: true}},
+			{Tag: 3, Name: "b", Kind: metadata.Uint64Kind},
+			{Tag: 4, Name: "d", Kind: metadata.Int32Kind, Options: metadata.FieldOptions{Int64AsString: true}},
+		},
+	}
+	msgmd.BakeTagIndex()
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, 1<<60)
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendBytes(b, protowire.AppendVarint(protowire.AppendVarint(nil, protowire.EncodeZigZag(-1)), 2))
+	for _, c := range []struct {
+		all    bool
+		expect string
+	}{
+		{false, `{"a":"1152921504606846976","c":["-1","1"],"b":0,"d":0}`},
+		{true, `{"a":"1152921504606846976","c":["-1","1"],"b":"0","d":0}`},
+	} {
+		e := NewEncoder(nil)
+		e.Int64AsString = c.all
+		e.EncodeMessage(msgmd, b)
+		if e.Error() != nil {
+			t.Fatal(e.Error())
+		}
+		if string(e.Bytes()) != c.expect {
+			t.Fatalf("got %s, want %s", e.Bytes(), c.expect)
+		}
+		e.Reset()
+		e.EncodeMessageFast(msgmd, b)
+		if e.Error() != nil {
+			t.Fatal(e.Error())
+		}
+		if string(e.Bytes()) != c.expect {
+			t.Fatalf("fast: got %s, want %s", e.Bytes(), c.expect)
+		}
+	}
+}
+
+func TestEncodeInt64WrapperAsString(t *testing.T) {
+	wrapper := func(name string, wk metadata.WellKnownType, kind metadata.TypeKind) *metadata.Message {
+		msg := &metadata.Message{
+			Name:      name,
+			Fields:    []*metadata.Field{{Tag: 1, Name: "value", Kind: kind}},
+			WellKnown: wk,
+		}
+		msg.BakeTagIndex()
+		return msg
+	}
+	i64 := wrapper(".google.protobuf.Int64Value", metadata.Int64ValueType, metadata.Int64Kind)
+	u64 := wrapper(".google.protobuf.UInt64Value", metadata.UInt64ValueType, metadata.Uint64Kind)
+	entry := &metadata.Message{
+		Name: "testmsg.MEntry",
+		Fields: []*metadata.Field{
+			{Tag: 1, Name: "key", Kind: metadata.StringKind},
+			{Tag: 2, Name: "value", Kind: metadata.MessageKind, Message: i64, Options: metadata.FieldOptions{Int64AsString: true}},
+		},
+	}
+	entry.BakeTagIndex()
+	quoted := metadata.FieldOptions{Int64AsString: true}
+	msgmd := &metadata.Message{
+		Name: "testmsg",
+		Fields: []*metadata.Field{
+			{Tag: 1, Name: "a", Kind: metadata.MessageKind, Message: i64, Options: quoted},
+			{Tag: 2, Name: "b", Kind: metadata.MessageKind, Message: u64, Options: quoted},
+			{Tag: 3, Name: "c", Kind: metadata.MessageKind, Message: i64},
+			{Tag: 4, Name: "d", Kind: metadata.MessageKind, Message: i64, Options: quoted},
+			{Tag: 5, Name: "m", Kind: metadata.MapKind, Repeated: true, Message: entry, Options: quoted},
+		},
+	}
+	msgmd.BakeTagIndex()
+
+	appendMessage := func(b []byte, tag protowire.Number, m []byte) []byte {
+		b = protowire.AppendTag(b, tag, protowire.BytesType)
+		return protowire.AppendBytes(b, m)
+	}
+	value := func(x uint64) []byte {
+		return protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), x)
+	}
+	var b []byte
+	b = appendMessage(b, 1, value(1<<60))
+	b = appendMessage(b, 2, value(1<<63))
+	b = appendMessage(b, 3, value(1))
+	b = appendMessage(b, 4, nil)
+	var kv []byte
+	kv = appendMessage(kv, 1, []byte("k"))
+	kv = appendMessage(kv, 2, value(2))
+	b = appendMessage(b, 5, kv)
+	expect := `{"a":"1152921504606846976","b":"9223372036854775808","c":1,"d":"0","m":{"k":"2"}}`
+
+	e := NewEncoder(nil)
+	e.EncodeMessage(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != expect {
+		t.Fatalf("got %s, want %s", e.Bytes(), expect)
+	}
+	e.Reset()
+	e.EncodeMessageFast(msgmd, b)
+	if e.Error() != nil {
+		t.Fatal(e.Error())
+	}
+	if string(e.Bytes()) != expect {
+		t.Fatalf("fast: got %s, want %s", e.Bytes(), expect)
+	}
+	if e.Int64AsString {
+		t.Fatal("Int64AsString of the encoder changed")
+	}
+}
@@ -23,6 +23,11 @@ type fieldValue struct {
 type Encoder struct {
 	buf []byte
 	err error
+	// Naming is the style of keys, it's kept by Reset.
+	Naming metadata.Naming
+	// Int64AsString writes 64-bit integers of all fields as strings, see
+	// FieldOptions.Int64AsString.
+	Int64AsString bool
 }
 
 func (e *Encoder) Error() error {
@@ -85,10 +90,10 @@ func (e *Encoder) consume(pb *proto.Buffer, wireType int) (out protoValue) {
 	return
 }
 
-func (e *Encoder) emitPackedValue(kind metadata.TypeKind, b []byte, more bool) bool {
+func (e *Encoder) emitPackedValue(field *metadata.Field, b []byte, more bool) bool {
 	pb := newProtoBuffer(b)
 	var decode func() (uint64, error)
-	switch wireTypeOfKind[kind] {
+	switch wireTypeOfKind[field.Kind] {
 	case proto.WireVarint:
 		decode = pb.DecodeVarint
 	case proto.WireFixed32:
@@ -98,7 +103,17 @@ func (e *Encoder) emitPackedValue(kind metadata.TypeKind, b []byte, more bool) b
 	default:
 		panic("unreachable")
 	}
-	write := writePrimary[kind]
+	write := writePrimary[field.Kind]
+	switch {
+	case field.Kind == metadata.EnumKind:
+		write = func(e *Encoder, x uint64) {
+			e.encodeEnum(field, x)
+		}
+	case e.int64AsString(field):
+		write = func(e *Encoder, x uint64) {
+			e.encodePrimary(field, x)
+		}
+	}
 	for {
 		x, err := decode()
 		if err != nil {
@@ -128,7 +143,7 @@ func (e *Encoder) encodeRepeatedValue(field *metadata.Field, fv *fieldValue) {
 	for {
 		if pv.b != nil && wire != proto.WireBytes {
 			// https://developers.google.cn/protocol-buffers/docs/encoding#packed
-			more = e.emitPackedValue(field.Kind, pv.b, more)
+			more = e.emitPackedValue(field, pv.b, more)
 		} else {
 			if more {
 				e.WriteByte(',')
@@ -172,9 +187,56 @@ func (e *Encoder) encodeValue(field *metadata.Field, pv *protoValue) {
 			e.buf = e.buf[:m+n]
 		}
 	case metadata.MessageKind:
+		if field.Message.WellKnown.IsWrapper() {
+			e.encodeWrapper(field.Message, pv.b, field.Options.Int64AsString)
+			return
+		}
 		e.EncodeMessage(field.Message, pv.b)
+	case metadata.EnumKind:
+		e.encodeEnum(field, pv.x)
 	default:
-		writePrimary[field.Kind](e, pv.x)
+		e.encodePrimary(field, pv.x)
+	}
+}
+
+func (e *Encoder) int64AsString(field *metadata.Field) bool {
+	return (e.Int64AsString || field.Options.Int64AsString) && is64BitInteger(field.Kind)
+}
+
+// encodePrimary writes numbers and bools, 64-bit integers may be quoted.
+func (e *Encoder) encodePrimary(field *metadata.Field, x uint64) {
+	if e.int64AsString(field) {
+		e.WriteByte('"')
+		writePrimary[field.Kind](e, x)
+		e.WriteByte('"')
+		return
+	}
+	writePrimary[field.Kind](e, x)
+}
+
+func (e *Encoder) encodeEnum(field *metadata.Field, x uint64) {
+	if field.Options.EnumAsString && field.Enum != nil {
+		// unknown numbers are kept as is
+		if v := field.Enum.FindNumber(int32(x)); v != nil {
+			e.WriteByte('"')
+			e.WriteString(v.Name)
+			e.WriteByte('"')
+			return
+		}
+	}
+	appendI64(e, x)
+}
+
+func (e *Encoder) writeDefaultValue(field *metadata.Field) {
+	switch {
+	case field.Kind == metadata.EnumKind:
+		e.encodeEnum(field, 0)
+	case field.Kind == metadata.MessageKind && field.Message.WellKnown != metadata.NotWellKnown:
+		e.WriteString("null")
+	case e.int64AsString(field):
+		e.WriteString(`"0"`)
+	default:
+		e.WriteString(defaultValues[field.Kind])
 	}
 }
 
@@ -185,7 +247,8 @@ func (e *Encoder) emitMessage(msg *metadata.Message, values []fieldValue) {
 	more := false
 	for i, field := range msg.Fields {
 		fv := &values[i]
-		if !fv.assigned && field.Options.OmitEmpty {
+		if !fv.assigned && (field.Options.OmitEmpty || field.Oneof != nil || field.Optional) {
+			// unset fields with explicit presence are always omitted
 			continue
 		}
 		if more {
@@ -194,13 +257,13 @@ func (e *Encoder) emitMessage(msg *metadata.Message, values []fieldValue) {
 			more = true
 		}
 		e.WriteByte('"')
-		e.WriteString(field.Name) // direct write field name as json object key
+		e.WriteString(field.Key(e.Naming)) // direct write field name as json object key
 		e.WriteByte2('"', ':')
 		if !fv.assigned {
 			if field.Repeated && field.Kind != metadata.MapKind {
 				e.WriteByte2('[', ']')
 			} else {
-				e.WriteString(defaultValues[field.Kind])
+				e.writeDefaultValue(field)
 			}
 			continue
 		}
@@ -291,7 +354,7 @@ func (e *Encoder) encodeMap(msg *metadata.Message, fv *fieldValue) {
 		if entry[1].assigned {
 			e.encodeValue(valueType, &entry[1].pv)
 		} else {
-			e.WriteString(defaultValues[valueType.Kind])
+			e.writeDefaultValue(valueType)
 		}
 		if e.err != nil {
 			return
@@ -306,6 +369,10 @@ func (e *Encoder) encodeMap(msg *metadata.Message, fv *fieldValue) {
 }
 
 func (e *Encoder) EncodeMessage(msg *metadata.Message, data []byte) {
+	if msg.WellKnown != metadata.NotWellKnown {
+		e.encodeWellKnown(msg, data)
+		return
+	}
 	if len(msg.Fields) == 0 {
 		if !msg.Options.Flat {
 			e.WriteString("{}")
@@ -347,6 +414,14 @@ func (e *Encoder) EncodeMessage(msg *metadata.Message, data []byte) {
 			e.err = fmt.Errorf("expect wire type %d, got %d", wireTypeOfKind[field.Kind], wire)
 			break
 		}
+		if field.Oneof != nil {
+			// the last member of oneof wins
+			for _, f := range field.Oneof.Fields {
+				if f != field {
+					values[msg.TagIndex(f.Tag)] = fieldValue{}
+				}
+			}
+		}
 		v := &values[idx]
 		if !v.assigned || !field.Repeated {
 			// always overwrite
@@ -369,3 +444,24 @@ func (e *Encoder) EncodeMessage(msg *metadata.Message, data []byte) {
 func NewEncoder(buf []byte) *Encoder {
 	return &Encoder{buf: buf}
 }
+
+// EncodeField encodes the value of field in data, which is a message having
+// the field.
+func (e *Encoder) EncodeField(field *metadata.Field, data []byte) {
+	f := *field
+	// the value is always written
+	f.Oneof = nil
+	f.Optional = false
+	f.Options.OmitEmpty = false
+	msg := &metadata.Message{Fields: []*metadata.Field{&f}}
+	msg.BakeTagIndex()
+	start := len(e.buf)
+	e.EncodeMessage(msg, data)
+	if e.err != nil {
+		return
+	}
+	// strip {"name": and }
+	prefix := len(f.Key(e.Naming)) + 4
+	n := copy(e.buf[start:], e.buf[start+prefix:len(e.buf)-1])
+	e.buf = e.buf[:start+n]
+}
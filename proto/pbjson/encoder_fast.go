@@ -32,13 +32,23 @@ func (e *Encoder) encodeValueFast(field *metadata.Field, x uint64, buf []byte) {
 			e.buf = e.buf[:m+n]
 		}
 	case metadata.MessageKind:
+		if field.Message.WellKnown.IsWrapper() {
+			e.encodeWrapper(field.Message, buf, field.Options.Int64AsString)
+			return
+		}
 		e.EncodeMessageFast(field.Message, buf)
+	case metadata.EnumKind:
+		e.encodeEnum(field, x)
 	default:
-		writePrimary[field.Kind](e, x)
+		e.encodePrimary(field, x)
 	}
 }
 
 func (e *Encoder) EncodeMessageFast(msg *metadata.Message, data []byte) {
+	if msg.WellKnown != metadata.NotWellKnown {
+		e.encodeWellKnown(msg, data)
+		return
+	}
 	if len(msg.Fields) == 0 {
 		if !msg.Options.Flat {
 			e.WriteString("{}")
@@ -50,15 +60,28 @@ func (e *Encoder) EncodeMessageFast(msg *metadata.Message, data []byte) {
 	}
 	curTag := 0
 	var (
-		curField     *metadata.Field
-		fixedEmitted [32]bool
-		emitted      []bool
+		curField        *metadata.Field
+		fixedEmitted    [32]bool
+		emitted         []bool
+		fixedOverridden [32]bool
+		overridden      []bool
 	)
 	if len(msg.Fields) <= len(fixedEmitted) {
 		emitted = fixedEmitted[:]
 	} else {
 		emitted = make([]bool, len(msg.Fields))
 	}
+	if len(msg.Oneofs) > 0 {
+		if len(msg.Fields) <= len(fixedOverridden) {
+			overridden = fixedOverridden[:]
+		} else {
+			overridden = make([]bool, len(msg.Fields))
+		}
+		e.overriddenOneofs(msg, data, overridden)
+		if e.err != nil {
+			return
+		}
+	}
 	closeChar := byte(0)
 	more := false
 	more1 := false
@@ -97,7 +120,7 @@ func (e *Encoder) EncodeMessageFast(msg *metadata.Message, data []byte) {
 		// reuse field info
 		if tag != curTag {
 			idx := msg.TagIndex(tag)
-			if idx == -1 || emitted[idx] {
+			if idx == -1 || emitted[idx] || overridden != nil && overridden[idx] {
 				// ignore
 				continue
 			}
@@ -115,7 +138,7 @@ func (e *Encoder) EncodeMessageFast(msg *metadata.Message, data []byte) {
 				more = true
 			}
 			e.WriteByte('"')
-			e.WriteString(curField.Name)
+			e.WriteString(curField.Key(e.Naming))
 			e.WriteByte2('"', ':')
 			if curField.Repeated {
 				if curField.Kind == metadata.MapKind {
@@ -136,7 +159,7 @@ func (e *Encoder) EncodeMessageFast(msg *metadata.Message, data []byte) {
 			// array or map
 			if wire == proto.WireBytes && fwire != proto.WireBytes {
 				// packed
-				more1 = e.emitPackedValue(curField.Kind, buf, more1)
+				more1 = e.emitPackedValue(curField, buf, more1)
 				continue
 			}
 			if more1 {
@@ -161,7 +184,7 @@ func (e *Encoder) EncodeMessageFast(msg *metadata.Message, data []byte) {
 				if entry[1].assigned {
 					e.encodeValueFast(valueType, entry[1].pv.x, entry[1].pv.b)
 				} else {
-					e.WriteString(defaultValues[valueType.Kind])
+					e.writeDefaultValue(valueType)
 				}
 				if e.err != nil {
 					break
@@ -185,7 +208,7 @@ func (e *Encoder) EncodeMessageFast(msg *metadata.Message, data []byte) {
 		e.WriteByte(closeChar)
 	}
 	for i, field := range msg.Fields {
-		if emitted[i] || field.Options.OmitEmpty {
+		if emitted[i] || field.Options.OmitEmpty || field.Oneof != nil || field.Optional {
 			continue
 		}
 		if more {
@@ -194,15 +217,31 @@ func (e *Encoder) EncodeMessageFast(msg *metadata.Message, data []byte) {
 			more = true
 		}
 		e.WriteByte('"')
-		e.WriteString(field.Name)
+		e.WriteString(field.Key(e.Naming))
 		e.WriteByte2('"', ':')
 		if field.Repeated && field.Kind != metadata.MapKind {
 			e.WriteByte2('[', ']')
 		} else {
-			e.WriteString(defaultValues[field.Kind])
+			e.writeDefaultValue(field)
 		}
 	}
 	if !msg.Options.Flat {
 		e.WriteByte('}')
 	}
 }
+
+// overriddenOneofs marks members of oneofs followed by another member in data,
+// the last one wins like EncodeMessage.
+func (e *Encoder) overriddenOneofs(msg *metadata.Message, data []byte, overridden []bool) {
+	e.decodeFields(data, func(tag int, wire int, pv protoValue) {
+		idx := msg.TagIndex(tag)
+		if idx == -1 || msg.Fields[idx].Oneof == nil {
+			return
+		}
+		for _, f := range msg.Fields[idx].Oneof.Fields {
+			if i := msg.TagIndex(f.Tag); i != -1 {
+				overridden[i] = f.Tag != tag
+			}
+		}
+	})
+}
@@ -66,6 +66,14 @@ var writePrimary = [...]func(e *Encoder, x uint64){
 	metadata.DoubleKind:   appendF64,
 }
 
+func is64BitInteger(kind metadata.TypeKind) bool {
+	switch kind {
+	case metadata.Int64Kind, metadata.Uint64Kind, metadata.Sint64Kind, metadata.Fixed64Kind, metadata.Sfixed64Kind:
+		return true
+	}
+	return false
+}
+
 func appendS32(e *Encoder, x uint64) {
 	x = uint64((uint32(x) >> 1) ^ uint32((int32(x&1)<<31)>>31))
 	e.buf = strconv.AppendInt(e.buf, int64(x), 10)
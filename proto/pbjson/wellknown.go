@@ -0,0 +1,284 @@
+package pbjson
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+// https://developers.google.com/protocol-buffers/docs/proto3#json
+
+func (e *Encoder) decodeFields(data []byte, fn func(tag int, wire int, pv protoValue)) {
+	pb := newProtoBuffer(data)
+	for {
+		key, err := pb.DecodeVarint()
+		if err != nil {
+			if err != io.ErrUnexpectedEOF {
+				e.err = err
+			}
+			break
+		}
+		tag, wire := int(key>>3), int(key&7)
+		pv := e.consume(pb, wire)
+		if e.err != nil {
+			break
+		}
+		fn(tag, wire, pv)
+		if e.err != nil {
+			break
+		}
+	}
+	putProtoBuffer(pb)
+}
+
+func (e *Encoder) encodeWellKnown(msg *metadata.Message, data []byte) {
+	switch t := msg.WellKnown; {
+	case t == metadata.TimestampType:
+		e.encodeTimestamp(data)
+	case t == metadata.DurationType:
+		e.encodeDuration(data)
+	case t.IsWrapper():
+		e.encodeWrapper(msg, data, false)
+	case t == metadata.StructType:
+		e.encodeStruct(data)
+	case t == metadata.ValueType:
+		e.encodeStructValue(data)
+	case t == metadata.ListValueType:
+		e.encodeListValue(data)
+	case t == metadata.AnyType:
+		e.encodeAny(msg, data)
+	default:
+		e.err = fmt.Errorf("unsupported well-known type: %s", msg.Name)
+	}
+}
+
+func (e *Encoder) decodeSecondsAndNanos(data []byte) (seconds int64, nanos int32) {
+	e.decodeFields(data, func(tag int, wire int, pv protoValue) {
+		switch tag {
+		case 1:
+			seconds = int64(pv.x)
+		case 2:
+			nanos = int32(pv.x)
+		}
+	})
+	return
+}
+
+// appendNanos writes the fractional part with 3, 6 or 9 digits.
+func (e *Encoder) appendNanos(nanos int32) {
+	if nanos == 0 {
+		return
+	}
+	s := strconv.FormatInt(int64(nanos)+1e9, 10)[1:]
+	switch {
+	case nanos%1e6 == 0:
+		s = s[:3]
+	case nanos%1e3 == 0:
+		s = s[:6]
+	}
+	e.WriteByte('.')
+	e.WriteString(s)
+}
+
+func (e *Encoder) encodeTimestamp(data []byte) {
+	seconds, nanos := e.decodeSecondsAndNanos(data)
+	if e.err != nil {
+		return
+	}
+	if nanos < 0 || nanos >= 1e9 {
+		e.err = fmt.Errorf("invalid timestamp nanos: %d", nanos)
+		return
+	}
+	t := time.Unix(seconds, 0).UTC()
+	e.WriteByte('"')
+	e.buf = t.AppendFormat(e.buf, "2006-01-02T15:04:05")
+	e.appendNanos(nanos)
+	e.WriteByte2('Z', '"')
+}
+
+func (e *Encoder) encodeDuration(data []byte) {
+	seconds, nanos := e.decodeSecondsAndNanos(data)
+	if e.err != nil {
+		return
+	}
+	if nanos <= -1e9 || nanos >= 1e9 || (seconds > 0 && nanos < 0) || (seconds < 0 && nanos > 0) {
+		e.err = fmt.Errorf("invalid duration: %ds %dns", seconds, nanos)
+		return
+	}
+	e.WriteByte('"')
+	if seconds < 0 || nanos < 0 {
+		e.WriteByte('-')
+		seconds, nanos = -seconds, -nanos
+	}
+	e.buf = strconv.AppendUint(e.buf, uint64(seconds), 10)
+	e.appendNanos(nanos)
+	e.WriteByte2('s', '"')
+}
+
+// encodeWrapper writes the value of a wrapper, int64AsString is the option of
+// the field of the wrapper as the message is shared.
+func (e *Encoder) encodeWrapper(msg *metadata.Message, data []byte, int64AsString bool) {
+	field := msg.Fields[0]
+	if int64AsString && !e.Int64AsString {
+		e.Int64AsString = true
+		defer func() { e.Int64AsString = false }()
+	}
+	var fv fieldValue
+	e.decodeFields(data, func(tag int, wire int, pv protoValue) {
+		if tag == 1 {
+			fv = fieldValue{
+				assigned: true,
+				pv:       pv,
+			}
+		}
+	})
+	if e.err != nil {
+		return
+	}
+	if fv.assigned {
+		e.encodeValue(field, &fv.pv)
+	} else {
+		e.writeDefaultValue(field)
+	}
+}
+
+func (e *Encoder) encodeStruct(data []byte) {
+	e.WriteByte('{')
+	more := false
+	e.decodeFields(data, func(tag int, wire int, pv protoValue) {
+		if tag != 1 {
+			return
+		}
+		pb := newProtoBuffer(pv.b)
+		var entry [2]fieldValue
+		e.decodeEntry(pb, &entry)
+		putProtoBuffer(pb)
+		if e.err != nil {
+			return
+		}
+		if more {
+			e.WriteByte(',')
+		} else {
+			more = true
+		}
+		e.WriteSafeString(entry[0].pv.b)
+		e.WriteByte(':')
+		e.encodeStructValue(entry[1].pv.b)
+	})
+	if e.err != nil {
+		return
+	}
+	e.WriteByte('}')
+}
+
+func (e *Encoder) encodeStructValue(data []byte) {
+	var (
+		kind = 0
+		val  protoValue
+	)
+	// the last one wins
+	e.decodeFields(data, func(tag int, wire int, pv protoValue) {
+		kind = tag
+		val = pv
+	})
+	if e.err != nil {
+		return
+	}
+	switch kind {
+	case 2:
+		appendF64(e, val.x)
+	case 3:
+		e.WriteSafeString(val.b)
+	case 4:
+		appendBool(e, val.x)
+	case 5:
+		e.encodeStruct(val.b)
+	case 6:
+		e.encodeListValue(val.b)
+	default:
+		// null_value or kind not set
+		e.WriteString("null")
+	}
+}
+
+func (e *Encoder) encodeListValue(data []byte) {
+	e.WriteByte('[')
+	more := false
+	e.decodeFields(data, func(tag int, wire int, pv protoValue) {
+		if tag != 1 {
+			return
+		}
+		if more {
+			e.WriteByte(',')
+		} else {
+			more = true
+		}
+		e.encodeStructValue(pv.b)
+	})
+	if e.err != nil {
+		return
+	}
+	e.WriteByte(']')
+}
+
+func (e *Encoder) encodeAny(msg *metadata.Message, data []byte) {
+	var typeURL, value []byte
+	e.decodeFields(data, func(tag int, wire int, pv protoValue) {
+		switch tag {
+		case 1:
+			typeURL = pv.b
+		case 2:
+			value = pv.b
+		}
+	})
+	if e.err != nil {
+		return
+	}
+	if len(typeURL) == 0 {
+		e.WriteString("{}")
+		return
+	}
+	url := string(typeURL)
+	var packed *metadata.Message
+	if msg.Resolver != nil {
+		packed = msg.Resolver.FindMessage(url[strings.LastIndexByte(url, '/')+1:])
+	}
+	if packed == nil {
+		e.err = fmt.Errorf("unknown type of any: %s", url)
+		return
+	}
+	e.WriteString(`{"@type":`)
+	e.WriteSafeString(typeURL)
+	if packed.WellKnown != metadata.NotWellKnown {
+		e.WriteString(`,"value":`)
+		e.encodeWellKnown(packed, value)
+		e.WriteByte('}')
+		return
+	}
+	start := len(e.buf)
+	e.EncodeMessage(packed, value)
+	if e.err != nil {
+		return
+	}
+	switch {
+	case len(e.buf)-start == 2 && e.buf[start] == '{':
+		// empty message
+		e.buf[start] = '}'
+		e.buf = e.buf[:start+1]
+	case len(e.buf) > start && e.buf[start] == '{':
+		// merge fields into the object
+		e.buf[start] = ','
+	default:
+		// flat message
+		if len(e.buf) > start {
+			e.buf = append(e.buf, 0)
+			copy(e.buf[start+1:], e.buf[start:])
+			e.buf[start] = ','
+		}
+		e.WriteByte('}')
+	}
+}
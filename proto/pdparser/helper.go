@@ -66,3 +66,22 @@ func mapTypeToKind(t descriptor.FieldDescriptorProto_Type) metadata.TypeKind {
 		return metadata.InvalidType
 	}
 }
+
+// lowerCamelCase converts a field name the same way protoc derives json_name.
+func lowerCamelCase(name string) string {
+	b := make([]byte, 0, len(name))
+	upper := false
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_':
+			upper = true
+			continue
+		case upper && c >= 'a' && c <= 'z':
+			c -= 'a' - 'A'
+		}
+		upper = false
+		b = append(b, c)
+	}
+	return string(b)
+}
@@ -2,6 +2,7 @@ package pdparser
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -9,6 +10,8 @@ import (
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/zhiduoke/gapi/metadata"
 	annotation "github.com/zhiduoke/gapi/proto"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc/codes"
 )
 
 type pdServiceOption struct {
@@ -16,6 +19,9 @@ type pdServiceOption struct {
 	defaultHandler string
 	defaultTimeout int32
 	pathPrefix     string
+	defaultRetry   *annotation.Retry
+	balancer       string
+	naming         metadata.Naming
 }
 
 type pdService struct {
@@ -25,19 +31,40 @@ type pdService struct {
 	methods  []*pdMethod
 }
 
+// pdBinding is a route of a method, template is set for paths of
+// google.api.http. Empty options are inherited from the method.
+type pdBinding struct {
+	method       string
+	path         string
+	template     bool
+	body         string
+	responseBody string
+	use          []string
+	handler      string
+	headers      []string
+	values       []string
+}
+
 type pdMethodOption struct {
-	method  string
-	path    string
-	use     []string
-	timeout int32
-	handler string
+	bindings []*pdBinding
+	use      []string
+	timeout  int32
+	handler  string
+	upload   *annotation.Upload
+	headers  []string
+	values   []string
+	retry    *annotation.Retry
+	limits   *annotation.Limits
 }
 
 type pdMethod struct {
-	name string
-	opt  pdMethodOption
-	in   *metadata.Message
-	out  *metadata.Message
+	name            string
+	opt             pdMethodOption
+	in              *metadata.Message
+	out             *metadata.Message
+	clientStreaming bool
+	serverStreaming bool
+	idempotent      bool
 }
 
 type Parser struct {
@@ -45,7 +72,7 @@ type Parser struct {
 	nsstr        string
 	msgs         map[string]*metadata.Message
 	isEntry      map[string]bool
-	enums        map[string]bool
+	enums        map[string]*metadata.Enum
 	services     []*pdService
 	extraHandler func(msg *metadata.Message, md *descriptor.DescriptorProto)
 }
@@ -96,6 +123,9 @@ func (p *Parser) parseService(sd *descriptor.ServiceDescriptorProto) (*pdService
 			annotation.E_DefaultHandler,
 			annotation.E_DefaultTimeout,
 			annotation.E_PathPrefix,
+			annotation.E_DefaultRetry,
+			annotation.E_Balancer,
+			annotation.E_JsonNaming,
 		})
 		if err != nil {
 			return nil, err
@@ -105,6 +135,16 @@ func (p *Parser) parseService(sd *descriptor.ServiceDescriptorProto) (*pdService
 			defaultHandler: getString(opts[1], ""),
 			defaultTimeout: getInt32(opts[2], 0),
 			pathPrefix:     getString(opts[3], ""),
+			balancer:       getString(opts[5], ""),
+		}
+		svc.opt.defaultRetry, _ = opts[4].(*annotation.Retry)
+		if v, ok := opts[6].(*annotation.JSON_NAMING); ok && v != nil {
+			switch *v {
+			case annotation.JSON_NAMING_JSON_NAME:
+				svc.opt.naming = metadata.JSONNaming
+			case annotation.JSON_NAMING_CAMEL_CASE:
+				svc.opt.naming = metadata.CamelCaseNaming
+			}
 		}
 	}
 	for _, md := range sd.Method {
@@ -127,50 +167,167 @@ func (p *Parser) parseMethod(md *descriptor.MethodDescriptorProto) (*pdMethod, e
 	if md.Options == nil {
 		return nil, proto.ErrMissingExtension
 	}
-	httpOpt, err := proto.GetExtension(md.Options, annotation.E_Http)
-	if err != nil {
+	opts, err := proto.GetExtensions(md.Options, []*proto.ExtensionDesc{
+		annotation.E_Http,
+		annotations.E_Http,
+	})
+	if err != nil && err != proto.ErrMissingExtension {
 		return nil, err
 	}
-	opt, ok := httpOpt.(*annotation.Http)
-	if !ok {
-		return nil, fmt.Errorf("unrecognized http option: %T", httpOpt)
+	opt, _ := opts[0].(*annotation.Http)
+	rule, _ := opts[1].(*annotations.HttpRule)
+	if opt == nil && rule == nil {
+		return nil, proto.ErrMissingExtension
 	}
-	if opt.Pattern == nil {
+	if opt != nil {
+		if opt.Pattern != nil {
+			b, err := parsePattern(opt)
+			if err != nil {
+				return nil, err
+			}
+			method.opt.bindings = append(method.opt.bindings, b)
+		}
+		for _, additional := range opt.AdditionalBindings {
+			if additional.Timeout != 0 || additional.Upload != nil || additional.Retry != nil ||
+				additional.Limits != nil || len(additional.AdditionalBindings) > 0 {
+				return nil, fmt.Errorf("method %s: additional bindings can only set pattern, use, handler, forward_headers and forward_context", method.name)
+			}
+			if additional.Pattern == nil {
+				return nil, fmt.Errorf("method %s: pattern of additional binding is not defined", method.name)
+			}
+			b, err := parsePattern(additional)
+			if err != nil {
+				return nil, err
+			}
+			b.use = additional.Use
+			b.handler = additional.Handler
+			b.headers = additional.ForwardHeaders
+			b.values = additional.ForwardContext
+			method.opt.bindings = append(method.opt.bindings, b)
+		}
+	}
+	if rule != nil {
+		bindings, err := parseHttpRule(rule, true)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %v", method.name, err)
+		}
+		method.opt.bindings = append(method.opt.bindings, bindings...)
+	}
+	if len(method.opt.bindings) == 0 {
 		return nil, fmt.Errorf("pattern is not defined")
 	}
+	if opt == nil {
+		opt = &annotation.Http{}
+	}
+	method.opt.timeout = opt.Timeout
+	method.opt.handler = opt.Handler
+	method.opt.use = opt.Use
+	method.opt.upload = opt.Upload
+	method.opt.headers = opt.ForwardHeaders
+	method.opt.values = opt.ForwardContext
+	method.opt.retry = opt.Retry
+	method.opt.limits = opt.Limits
+	method.in = p.msgs[md.GetInputType()]
+	method.out = p.msgs[md.GetOutputType()]
+	method.clientStreaming = md.GetClientStreaming()
+	method.serverStreaming = md.GetServerStreaming()
+	switch md.Options.GetIdempotencyLevel() {
+	case descriptor.MethodOptions_IDEMPOTENT, descriptor.MethodOptions_NO_SIDE_EFFECTS:
+		method.idempotent = true
+	}
+	return method, nil
+}
 
+func parsePattern(opt *annotation.Http) (*pdBinding, error) {
+	b := &pdBinding{}
 	switch t := opt.Pattern.(type) {
 	case *annotation.Http_Post:
-		method.opt.method = "POST"
-		method.opt.path = t.Post
+		b.method = "POST"
+		b.path = t.Post
 	case *annotation.Http_Get:
-		method.opt.method = "GET"
-		method.opt.path = t.Get
+		b.method = "GET"
+		b.path = t.Get
 	case *annotation.Http_Put:
-		method.opt.method = "PUT"
-		method.opt.path = t.Put
+		b.method = "PUT"
+		b.path = t.Put
 	case *annotation.Http_Delete:
-		method.opt.method = "DELETE"
-		method.opt.path = t.Delete
+		b.method = "DELETE"
+		b.path = t.Delete
 	case *annotation.Http_Option:
-		method.opt.method = "OPTION"
-		method.opt.path = t.Option
+		b.method = "OPTION"
+		b.path = t.Option
 	case *annotation.Http_Patch:
-		method.opt.method = "PATCH"
-		method.opt.path = t.Patch
+		b.method = "PATCH"
+		b.path = t.Patch
 	default:
 		return nil, fmt.Errorf("unkonwn pattern %T", opt.Pattern)
 	}
-	method.opt.timeout = opt.Timeout
-	method.opt.handler = opt.Handler
-	method.opt.use = opt.Use
-	method.in = p.msgs[md.GetInputType()]
-	method.out = p.msgs[md.GetOutputType()]
-	return method, nil
+	return b, nil
+}
+
+// parseHttpRule returns bindings of a google.api.http rule, additional
+// bindings are allowed at the top level only.
+func parseHttpRule(rule *annotations.HttpRule, top bool) ([]*pdBinding, error) {
+	b := &pdBinding{
+		template:     true,
+		body:         rule.Body,
+		responseBody: rule.ResponseBody,
+	}
+	switch t := rule.Pattern.(type) {
+	case *annotations.HttpRule_Get:
+		b.method, b.path = "GET", t.Get
+	case *annotations.HttpRule_Put:
+		b.method, b.path = "PUT", t.Put
+	case *annotations.HttpRule_Post:
+		b.method, b.path = "POST", t.Post
+	case *annotations.HttpRule_Delete:
+		b.method, b.path = "DELETE", t.Delete
+	case *annotations.HttpRule_Patch:
+		b.method, b.path = "PATCH", t.Patch
+	case *annotations.HttpRule_Custom:
+		if t.Custom == nil || t.Custom.Kind == "" {
+			return nil, fmt.Errorf("missing kind of custom pattern")
+		}
+		b.method, b.path = strings.ToUpper(t.Custom.Kind), t.Custom.Path
+	default:
+		return nil, fmt.Errorf("pattern of google.api.http is not defined")
+	}
+	bindings := []*pdBinding{b}
+	for _, additional := range rule.AdditionalBindings {
+		if !top {
+			return nil, fmt.Errorf("additional bindings can't be nested")
+		}
+		more, err := parseHttpRule(additional, false)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, more...)
+	}
+	return bindings, nil
+}
+
+func (p *Parser) getEnum(name string) *metadata.Enum {
+	enum := p.enums[name]
+	if enum == nil {
+		enum = &metadata.Enum{
+			Name: name,
+		}
+		p.enums[name] = enum
+	}
+	return enum
 }
 
 func (p *Parser) parseEnum(ed *descriptor.EnumDescriptorProto) error {
-	p.enums[p.nsstr+"."+ed.GetName()] = true
+	enum := p.getEnum(p.nsstr + "." + ed.GetName())
+	values := make([]*metadata.EnumValue, 0, len(ed.Value))
+	for _, vd := range ed.Value {
+		values = append(values, &metadata.EnumValue{
+			Name:   vd.GetName(),
+			Number: vd.GetNumber(),
+		})
+	}
+	enum.Values = values
+	enum.BakeValueIndex()
 	return nil
 }
 
@@ -178,7 +335,8 @@ func (p *Parser) getMessage(name string) *metadata.Message {
 	msg := p.msgs[name]
 	if msg == nil {
 		msg = &metadata.Message{
-			Name: name,
+			Name:      name,
+			WellKnown: wellKnownTypes[name],
 		}
 		p.msgs[name] = msg
 	}
@@ -208,15 +366,28 @@ func (p *Parser) parseMessage(md *descriptor.DescriptorProto) error {
 		if md.Options.GetMapEntry() {
 			p.isEntry[fullName] = true
 		}
-		opt, err := proto.GetExtension(md.Options, annotation.E_Flat)
+		opts, err := proto.GetExtensions(md.Options, []*proto.ExtensionDesc{
+			annotation.E_Flat,
+			annotation.E_EnumsAsString,
+			annotation.E_Int64SAsString,
+		})
 		if err != nil && err != proto.ErrMissingExtension {
 			return err
 		}
 		if err == nil {
-			msg.Options.Flat = getBool(opt, false)
+			msg.Options.Flat = getBool(opts[0], false)
+			msg.Options.EnumsAsString = getBool(opts[1], false)
+			msg.Options.Int64sAsString = getBool(opts[2], false)
 		}
 	}
 
+	var oneofs []*metadata.Oneof
+	for _, od := range md.OneofDecl {
+		oneofs = append(oneofs, &metadata.Oneof{
+			Name: od.GetName(),
+		})
+	}
+
 	var fields []*metadata.Field
 	for _, fd := range md.Field {
 		kind := mapTypeToKind(fd.GetType())
@@ -224,10 +395,20 @@ func (p *Parser) parseMessage(md *descriptor.DescriptorProto) error {
 			continue
 		}
 		field := &metadata.Field{
-			Tag:      int(fd.GetNumber()),
-			Name:     fd.GetName(),
-			Kind:     kind,
-			Repeated: fd.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED,
+			Tag:       int(fd.GetNumber()),
+			Name:      fd.GetName(),
+			JSONName:  fd.GetJsonName(),
+			CamelName: lowerCamelCase(fd.GetName()),
+			Kind:      kind,
+			Repeated:  fd.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED,
+			Options: metadata.FieldOptions{
+				EnumAsString:  msg.Options.EnumsAsString,
+				Int64AsString: msg.Options.Int64sAsString,
+			},
+		}
+		if field.JSONName == "" {
+			// json_name isn't filled by some generators
+			field.JSONName = field.CamelName
 		}
 
 		if fd.Options != nil {
@@ -238,12 +419,17 @@ func (p *Parser) parseMessage(md *descriptor.DescriptorProto) error {
 				annotation.E_FromContext,
 				annotation.E_Validate,
 				annotation.E_Bind,
+				annotation.E_EnumAsString,
+				annotation.E_Int64AsString,
 			})
 			if err != nil && err != proto.ErrMissingExtension {
 				return err
 			}
 			if err == nil {
-				field.Name = getString(opts[0], field.Name)
+				if alias := getString(opts[0], ""); alias != "" {
+					// the alias is the key of all naming styles
+					field.Name, field.JSONName, field.CamelName = alias, "", ""
+				}
 				bind := metadata.FromDefault
 				// compatibility
 				if getBool(opts[3], false) {
@@ -264,19 +450,40 @@ func (p *Parser) parseMessage(md *descriptor.DescriptorProto) error {
 					}
 				}
 				field.Options = metadata.FieldOptions{
-					OmitEmpty: getBool(opts[1], false),
-					RawData:   getBool(opts[2], false),
-					Validate:  getBool(opts[4], false),
-					Bind:      bind,
+					OmitEmpty:     getBool(opts[1], false),
+					RawData:       getBool(opts[2], false),
+					Validate:      getBool(opts[4], false),
+					Bind:          bind,
+					EnumAsString:  getBool(opts[6], msg.Options.EnumsAsString),
+					Int64AsString: getBool(opts[7], msg.Options.Int64sAsString),
 				}
 			}
 		}
-		if kind == metadata.MessageKind {
+		switch kind {
+		case metadata.MessageKind:
 			msgName := fd.GetTypeName()
 			if !strings.HasPrefix(msgName, ".") {
 				msgName = fullName + "." + msgName
 			}
 			field.Message = p.getMessage(msgName)
+		case metadata.EnumKind:
+			enumName := fd.GetTypeName()
+			if !strings.HasPrefix(enumName, ".") {
+				enumName = fullName + "." + enumName
+			}
+			field.Enum = p.getEnum(enumName)
+		}
+		if fd.GetProto3Optional() {
+			// synthetic oneof is not exposed
+			field.Optional = true
+		} else if fd.OneofIndex != nil {
+			idx := int(fd.GetOneofIndex())
+			if idx < 0 || idx >= len(oneofs) {
+				return fmt.Errorf("invalid oneof index %d of field %s", idx, fd.GetName())
+			}
+			oneof := oneofs[idx]
+			oneof.Fields = append(oneof.Fields, field)
+			field.Oneof = oneof
 		}
 		fields = append(fields, field)
 	}
@@ -284,6 +491,12 @@ func (p *Parser) parseMessage(md *descriptor.DescriptorProto) error {
 	// TODO map entry field order
 
 	msg.Fields = fields
+	msg.Oneofs = nil
+	for _, oneof := range oneofs {
+		if len(oneof.Fields) > 0 {
+			msg.Oneofs = append(msg.Oneofs, oneof)
+		}
+	}
 	msg.BakeTagIndex()
 	msg.BakeNameField()
 
@@ -299,6 +512,7 @@ func (p *Parser) SetExtraHandler(fn func(msg *metadata.Message, md *descriptor.D
 }
 
 func (p *Parser) Resolve() {
+	p.resolveWellKnown()
 	// resolve map kind
 	for _, msg := range p.msgs {
 		for _, f := range msg.Fields {
@@ -307,6 +521,12 @@ func (p *Parser) Resolve() {
 			}
 			if p.isEntry[f.Message.Name] {
 				f.Kind = metadata.MapKind
+				// map values follow the options of the map field
+				if len(f.Message.Fields) == 2 {
+					value := f.Message.Fields[1]
+					value.Options.EnumAsString = value.Options.EnumAsString || f.Options.EnumAsString
+					value.Options.Int64AsString = value.Options.Int64AsString || f.Options.Int64AsString
+				}
 			}
 		}
 	}
@@ -333,41 +553,266 @@ func (p *Parser) CollectRoutes() ([]*metadata.Route, error) {
 			if method.opt.timeout != 0 {
 				timeout = method.opt.timeout
 			}
-			path := method.opt.path
-			if path == "" {
-				return nil, fmt.Errorf("missing route path of method %s", method.name)
+			var upload *metadata.Upload
+			if method.opt.upload != nil {
+				var err error
+				upload, err = parseUpload(method, method.opt.upload)
+				if err != nil {
+					return nil, err
+				}
 			}
-			if path[0] != '/' {
-				return nil, fmt.Errorf("path %s must start with '/'", path)
+			retry, err := parseRetry(method, svc.opt.defaultRetry)
+			if err != nil {
+				return nil, err
 			}
-			if prefix != "" {
-				path = prefix + path
+			limits, err := parseLimits(method, method.opt.limits)
+			if err != nil {
+				return nil, err
+			}
+			mws, err := parseUses(method, method.opt.use)
+			if err != nil {
+				return nil, err
+			}
+			// routes of the method share the call unless they have their
+			// own handler or google.api.http binding
+			call := &metadata.Call{
+				Server:          svc.opt.server,
+				Balancer:        svc.opt.balancer,
+				Handler:         handler,
+				Name:            fmt.Sprintf("/%s/%s", svc.fullname, method.name),
+				In:              method.in,
+				Out:             method.out,
+				Timeout:         time.Duration(timeout) * time.Millisecond,
+				ClientStreaming: method.clientStreaming,
+				ServerStreaming: method.serverStreaming,
+				Upload:          upload,
+				Retry:           retry,
+				Naming:          svc.opt.naming,
+				Limits:          limits,
+			}
+			for _, b := range method.opt.bindings {
+				path := b.path
+				if path == "" {
+					return nil, fmt.Errorf("missing route path of method %s", method.name)
+				}
+				if path[0] != '/' {
+					return nil, fmt.Errorf("path %s must start with '/'", path)
+				}
+				if prefix != "" {
+					path = prefix + path
+				}
+				route := &metadata.Route{
+					Method: b.method,
+					Path:   path,
+					Options: metadata.RouteOptions{
+						Middlewares:    mws,
+						ForwardHeaders: method.opt.headers,
+						ForwardContext: method.opt.values,
+					},
+					Call: call,
+				}
+				if b.use != nil {
+					route.Options.Middlewares, err = parseUses(method, b.use)
+					if err != nil {
+						return nil, err
+					}
+				}
+				if b.headers != nil {
+					route.Options.ForwardHeaders = b.headers
+				}
+				if b.values != nil {
+					route.Options.ForwardContext = b.values
+				}
+				if b.template || b.handler != "" && b.handler != handler {
+					c := *call
+					if b.handler != "" {
+						c.Handler = b.handler
+					}
+					if b.template {
+						c.Binding, err = parseBinding(method, b, path)
+						if err != nil {
+							return nil, err
+						}
+					}
+					route.Call = &c
+				}
+				routes = append(routes, route)
 			}
-			routes = append(routes, &metadata.Route{
-				Method: method.opt.method,
-				Path:   path,
-				Options: metadata.RouteOptions{
-					Middlewares: method.opt.use,
-				},
-				Call: &metadata.Call{
-					Server:  svc.opt.server,
-					Handler: handler,
-					Name:    fmt.Sprintf("/%s/%s", svc.fullname, method.name),
-					In:      method.in,
-					Out:     method.out,
-					Timeout: time.Duration(timeout) * time.Millisecond,
-				},
-			})
 		}
 	}
 	return routes, nil
 }
 
+func parseUses(method *pdMethod, uses []string) ([]*metadata.Middleware, error) {
+	mws := make([]*metadata.Middleware, 0, len(uses))
+	for _, use := range uses {
+		mw, err := metadata.ParseMiddleware(use)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %v", method.name, err)
+		}
+		mws = append(mws, mw)
+	}
+	return mws, nil
+}
+
+// parseBinding resolves fields of a google.api.http binding.
+func parseBinding(method *pdMethod, b *pdBinding, path string) (*metadata.Binding, error) {
+	template, err := metadata.ParseTemplate(path)
+	if err != nil {
+		return nil, fmt.Errorf("method %s: %v", method.name, err)
+	}
+	binding := &metadata.Binding{
+		Template: template,
+		Body:     b.body,
+	}
+	for _, v := range template.Variables {
+		fields, err := resolveFieldPath(method.in, v.FieldPath)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %v", method.name, err)
+		}
+		binding.Variables = append(binding.Variables, fields)
+	}
+	if b.body != "" && b.body != "*" {
+		binding.BodyField = method.in.GetField(b.body)
+		if binding.BodyField == nil {
+			return nil, fmt.Errorf("body field %s not found in %s", b.body, method.in.Name)
+		}
+	}
+	if b.responseBody != "" {
+		binding.ResponseBody = method.out.GetField(b.responseBody)
+		if binding.ResponseBody == nil {
+			return nil, fmt.Errorf("response body field %s not found in %s", b.responseBody, method.out.Name)
+		}
+	}
+	return binding, nil
+}
+
+// resolveFieldPath returns fields of a path like a.b.c, which must end with
+// a scalar field.
+func resolveFieldPath(msg *metadata.Message, path string) ([]*metadata.Field, error) {
+	var fields []*metadata.Field
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		field := msg.GetField(name)
+		if field == nil {
+			return nil, fmt.Errorf("field %s of %s not found in %s", name, path, msg.Name)
+		}
+		if field.Repeated {
+			return nil, fmt.Errorf("field %s of %s can't be repeated", name, path)
+		}
+		fields = append(fields, field)
+		if i == len(names)-1 {
+			if field.Kind == metadata.MessageKind {
+				return nil, fmt.Errorf("field %s of %s must be a scalar", name, path)
+			}
+			break
+		}
+		if field.Kind != metadata.MessageKind {
+			return nil, fmt.Errorf("field %s of %s must be a message", name, path)
+		}
+		msg = field.Message
+	}
+	return fields, nil
+}
+
+const defaultChunkSize = 64 << 10
+
+func parseUpload(method *pdMethod, opt *annotation.Upload) (*metadata.Upload, error) {
+	if !method.clientStreaming || method.serverStreaming {
+		return nil, fmt.Errorf("upload method %s must be client-streaming", method.name)
+	}
+	field := method.in.GetField(opt.ChunkField)
+	if field == nil {
+		return nil, fmt.Errorf("chunk field %s not found in %s", opt.ChunkField, method.in.Name)
+	}
+	if field.Kind != metadata.BytesKind || field.Repeated {
+		return nil, fmt.Errorf("chunk field %s of %s must be bytes", opt.ChunkField, method.in.Name)
+	}
+	size := int(opt.ChunkSize)
+	if size < 0 {
+		return nil, fmt.Errorf("invalid chunk size of method %s: %d", method.name, size)
+	}
+	if size == 0 {
+		size = defaultChunkSize
+	}
+	return &metadata.Upload{
+		ChunkField: field,
+		ChunkSize:  size,
+		FormFile:   opt.FormFile,
+	}, nil
+}
+
+func parseLimits(method *pdMethod, opt *annotation.Limits) (metadata.Limits, error) {
+	if opt == nil {
+		return metadata.Limits{}, nil
+	}
+	if opt.MaxBodyBytes < 0 || opt.MaxDepth < 0 || opt.MaxArrayLength < 0 ||
+		opt.MaxMapEntries < 0 || opt.MaxStringLength < 0 {
+		return metadata.Limits{}, fmt.Errorf("invalid limits of method %s", method.name)
+	}
+	return metadata.Limits{
+		MaxBodyBytes:    opt.MaxBodyBytes,
+		MaxDepth:        int(opt.MaxDepth),
+		MaxArrayLength:  int(opt.MaxArrayLength),
+		MaxMapEntries:   int(opt.MaxMapEntries),
+		MaxStringLength: int(opt.MaxStringLength),
+	}, nil
+}
+
+// parseRetry returns the retry policy of an unary idempotent method, the one
+// of the method overrides the default one of its service.
+func parseRetry(method *pdMethod, defaultRetry *annotation.Retry) (*metadata.RetryPolicy, error) {
+	opt := defaultRetry
+	if method.opt.retry != nil {
+		if !method.idempotent || method.clientStreaming || method.serverStreaming {
+			return nil, fmt.Errorf("retry of method %s requires an unary idempotent method", method.name)
+		}
+		opt = method.opt.retry
+	}
+	if opt == nil || !method.idempotent || method.clientStreaming || method.serverStreaming {
+		return nil, nil
+	}
+	if opt.MaxAttempts < 0 || opt.InitialBackoff < 0 || opt.MaxBackoff < 0 ||
+		opt.BackoffMultiplier < 0 || opt.HedgingDelay < 0 {
+		return nil, fmt.Errorf("invalid retry of method %s", method.name)
+	}
+	policy := &metadata.RetryPolicy{
+		MaxAttempts:       int(opt.MaxAttempts),
+		InitialBackoff:    time.Duration(opt.InitialBackoff) * time.Millisecond,
+		MaxBackoff:        time.Duration(opt.MaxBackoff) * time.Millisecond,
+		BackoffMultiplier: opt.BackoffMultiplier,
+		HedgingDelay:      time.Duration(opt.HedgingDelay) * time.Millisecond,
+	}
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = 3
+	}
+	if policy.InitialBackoff == 0 {
+		policy.InitialBackoff = 100 * time.Millisecond
+	}
+	if policy.MaxBackoff == 0 {
+		policy.MaxBackoff = time.Second
+	}
+	if policy.BackoffMultiplier == 0 {
+		policy.BackoffMultiplier = 2
+	}
+	for _, name := range opt.RetryableCodes {
+		var code codes.Code
+		if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(name)))); err != nil {
+			return nil, fmt.Errorf("invalid retryable code of method %s: %s", method.name, name)
+		}
+		policy.RetryableCodes = append(policy.RetryableCodes, code)
+	}
+	if len(policy.RetryableCodes) == 0 {
+		policy.RetryableCodes = []codes.Code{codes.Unavailable}
+	}
+	return policy, nil
+}
+
 func NewParser() *Parser {
 	return &Parser{
 		msgs:    map[string]*metadata.Message{},
 		isEntry: map[string]bool{},
-		enums:   map[string]bool{},
+		enums:   map[string]*metadata.Enum{},
 	}
 }
 
@@ -389,5 +834,5 @@ func ParseSet(data []byte) (*metadata.Metadata, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &metadata.Metadata{Routes: routes}, nil
+	return &metadata.Metadata{Routes: routes, Resolver: p}, nil
 }
@@ -3,6 +3,11 @@ package pdparser
 import (
 	"io/ioutil"
 	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/zhiduoke/gapi/metadata"
+	annotation "github.com/zhiduoke/gapi/proto"
 )
 
 func TestParse(t *testing.T) {
@@ -19,3 +24,93 @@ func TestParse(t *testing.T) {
 		t.Logf(">> %+v", route.Call)
 	}
 }
+
+func TestAdditionalBindings(t *testing.T) {
+	data, err := ioutil.ReadFile("../../examples/demo/api/http.pd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	md, err := ParseSet(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var routes []*metadata.Route
+	for _, route := range md.Routes {
+		if route.Call.Name == "/service.demo.DemoAPI/Add" {
+			routes = append(routes, route)
+		}
+	}
+	if len(routes) != 2 {
+		t.Fatalf("routes of Add: %d", len(routes))
+	}
+	if routes[0].Method+" "+routes[0].Path != "POST /demo/add" || routes[1].Method+" "+routes[1].Path != "GET /demo/add" {
+		t.Fatalf("unexpected routes: %s %s, %s %s", routes[0].Method, routes[0].Path, routes[1].Method, routes[1].Path)
+	}
+	if routes[0].Call != routes[1].Call {
+		t.Fatal("bindings should share the call")
+	}
+}
+
+func TestMapValueOptions(t *testing.T) {
+	entry := func(name string, value *descriptor.FieldDescriptorProto) *descriptor.DescriptorProto {
+		value.Name = proto.String("value")
+		value.Number = proto.Int32(2)
+		return &descriptor.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptor.FieldDescriptorProto{
+				{Name: proto.String("key"), Number: proto.Int32(1), Type: descriptor.FieldDescriptorProto_TYPE_STRING.Enum()},
+				value,
+			},
+			Options: &descriptor.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+	}
+	mapField := func(name string, tag int32, entry string) *descriptor.FieldDescriptorProto {
+		opts := &descriptor.FieldOptions{}
+		if err := proto.SetExtension(opts, annotation.E_Int64AsString, proto.Bool(true)); err != nil {
+			t.Fatal(err)
+		}
+		return &descriptor.FieldDescriptorProto{
+			Name:     proto.String(name),
+			Number:   proto.Int32(tag),
+			Label:    descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+			Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: proto.String(".test.M." + entry),
+			Options:  opts,
+		}
+	}
+	file := &descriptor.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("test"),
+		MessageType: []*descriptor.DescriptorProto{{
+			Name: proto.String("M"),
+			Field: []*descriptor.FieldDescriptorProto{
+				mapField("a", 1, "AEntry"),
+				mapField("b", 2, "BEntry"),
+			},
+			NestedType: []*descriptor.DescriptorProto{
+				entry("AEntry", &descriptor.FieldDescriptorProto{Type: descriptor.FieldDescriptorProto_TYPE_INT64.Enum()}),
+				entry("BEntry", &descriptor.FieldDescriptorProto{
+					Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+					TypeName: proto.String(".google.protobuf.Int64Value"),
+				}),
+			},
+		}},
+	}
+	p := NewParser()
+	if err := p.AddFile(file); err != nil {
+		t.Fatal(err)
+	}
+	p.Resolve()
+	msg := p.FindMessage("test.M")
+	if msg == nil {
+		t.Fatal("message not found")
+	}
+	for _, f := range msg.Fields {
+		if f.Kind != metadata.MapKind {
+			t.Fatalf("kind of %s: %v", f.Name, f.Kind)
+		}
+		if !f.Message.Fields[1].Options.Int64AsString {
+			t.Fatalf("value of %s isn't int64_as_string", f.Name)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package pdparser
+
+import (
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+var wellKnownTypes = map[string]metadata.WellKnownType{
+	".google.protobuf.Timestamp":   metadata.TimestampType,
+	".google.protobuf.Duration":    metadata.DurationType,
+	".google.protobuf.DoubleValue": metadata.DoubleValueType,
+	".google.protobuf.FloatValue":  metadata.FloatValueType,
+	".google.protobuf.Int64Value":  metadata.Int64ValueType,
+	".google.protobuf.UInt64Value": metadata.UInt64ValueType,
+	".google.protobuf.Int32Value":  metadata.Int32ValueType,
+	".google.protobuf.UInt32Value": metadata.UInt32ValueType,
+	".google.protobuf.BoolValue":   metadata.BoolValueType,
+	".google.protobuf.StringValue": metadata.StringValueType,
+	".google.protobuf.BytesValue":  metadata.BytesValueType,
+	".google.protobuf.Struct":      metadata.StructType,
+	".google.protobuf.Value":       metadata.ValueType,
+	".google.protobuf.ListValue":   metadata.ListValueType,
+	".google.protobuf.Any":         metadata.AnyType,
+}
+
+var wrapperKinds = map[metadata.WellKnownType]metadata.TypeKind{
+	metadata.DoubleValueType: metadata.DoubleKind,
+	metadata.FloatValueType:  metadata.FloatKind,
+	metadata.Int64ValueType:  metadata.Int64Kind,
+	metadata.UInt64ValueType: metadata.Uint64Kind,
+	metadata.Int32ValueType:  metadata.Int32Kind,
+	metadata.UInt32ValueType: metadata.Uint32Kind,
+	metadata.BoolValueType:   metadata.BoolKind,
+	metadata.StringValueType: metadata.StringKind,
+	metadata.BytesValueType:  metadata.BytesKind,
+}
+
+// wellKnownFields returns the fields of well-known types, it's used when the
+// descriptor set doesn't include google/protobuf/*.proto.
+func (p *Parser) wellKnownFields(msg *metadata.Message) []*metadata.Field {
+	switch t := msg.WellKnown; {
+	case t == metadata.TimestampType, t == metadata.DurationType:
+		return []*metadata.Field{
+			{Tag: 1, Name: "seconds", Kind: metadata.Int64Kind},
+			{Tag: 2, Name: "nanos", Kind: metadata.Int32Kind},
+		}
+	case t.IsWrapper():
+		return []*metadata.Field{
+			{Tag: 1, Name: "value", Kind: wrapperKinds[t]},
+		}
+	case t == metadata.StructType:
+		entry := p.getMessage(".google.protobuf.Struct.FieldsEntry")
+		if len(entry.Fields) == 0 {
+			entry.Fields = []*metadata.Field{
+				{Tag: 1, Name: "key", Kind: metadata.StringKind},
+				{Tag: 2, Name: "value", Kind: metadata.MessageKind, Message: p.getMessage(".google.protobuf.Value")},
+			}
+			entry.BakeTagIndex()
+			entry.BakeNameField()
+		}
+		return []*metadata.Field{
+			{Tag: 1, Name: "fields", Kind: metadata.MapKind, Message: entry, Repeated: true},
+		}
+	case t == metadata.ValueType:
+		nullValue := p.getEnum(".google.protobuf.NullValue")
+		if len(nullValue.Values) == 0 {
+			nullValue.Values = []*metadata.EnumValue{{Name: "NULL_VALUE", Number: 0}}
+			nullValue.BakeValueIndex()
+		}
+		return []*metadata.Field{
+			{Tag: 1, Name: "null_value", Kind: metadata.EnumKind, Enum: nullValue},
+			{Tag: 2, Name: "number_value", Kind: metadata.DoubleKind},
+			{Tag: 3, Name: "string_value", Kind: metadata.StringKind},
+			{Tag: 4, Name: "bool_value", Kind: metadata.BoolKind},
+			{Tag: 5, Name: "struct_value", Kind: metadata.MessageKind, Message: p.getMessage(".google.protobuf.Struct")},
+			{Tag: 6, Name: "list_value", Kind: metadata.MessageKind, Message: p.getMessage(".google.protobuf.ListValue")},
+		}
+	case t == metadata.ListValueType:
+		return []*metadata.Field{
+			{Tag: 1, Name: "values", Kind: metadata.MessageKind, Message: p.getMessage(".google.protobuf.Value"), Repeated: true},
+		}
+	case t == metadata.AnyType:
+		return []*metadata.Field{
+			{Tag: 1, Name: "type_url", Kind: metadata.StringKind},
+			{Tag: 2, Name: "value", Kind: metadata.BytesKind},
+		}
+	}
+	return nil
+}
+
+func (p *Parser) resolveWellKnown() {
+	for {
+		// collect first, wellKnownFields may add messages
+		var missing []*metadata.Message
+		for _, msg := range p.msgs {
+			if msg.WellKnown != metadata.NotWellKnown && len(msg.Fields) == 0 {
+				missing = append(missing, msg)
+			}
+		}
+		if len(missing) == 0 {
+			break
+		}
+		for _, msg := range missing {
+			msg.Fields = p.wellKnownFields(msg)
+			msg.BakeTagIndex()
+			msg.BakeNameField()
+		}
+	}
+	for _, msg := range p.msgs {
+		if msg.WellKnown == metadata.AnyType {
+			msg.Resolver = p
+		}
+	}
+}
+
+// FindMessage implements metadata.MessageResolver.
+func (p *Parser) FindMessage(name string) *metadata.Message {
+	return p.msgs["."+name]
+}
@@ -0,0 +1,91 @@
+package gapi
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// retry sends attempts of an unary call until one succeeds, fails with a
+// code which isn't retryable, is rejected by the breaker or the attempts run
+// out. With hedging, another attempt is sent if no response in HedgingDelay
+// and the first response wins.
+func (h *routeHandler) retry(rpcctx context.Context, p *metadata.RetryPolicy, req []byte) *attemptResult {
+	var (
+		results = make(chan *attemptResult, p.MaxAttempts)
+		cancels []context.CancelFunc
+		last    *attemptResult
+		started int
+		pending int
+		backoff = p.InitialBackoff
+		next    <-chan time.Time
+		done    = rpcctx.Done()
+		stopped bool
+	)
+	defer func() {
+		// cancel the losers of hedging
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}()
+	start := func() {
+		actx, cancel := context.WithCancel(rpcctx)
+		cancels = append(cancels, cancel)
+		started++
+		pending++
+		go func() {
+			results <- h.attempt(actx, req)
+		}()
+		next = nil
+		if p.HedgingDelay > 0 && started < p.MaxAttempts {
+			next = time.After(p.HedgingDelay)
+		}
+	}
+	start()
+	for {
+		select {
+		case res := <-results:
+			pending--
+			if _, ok := res.err.(*breakerError); ok {
+				// fail fast, wait for pending attempts only
+				last = res
+				started = p.MaxAttempts
+				next = nil
+				if pending == 0 {
+					return last
+				}
+				continue
+			}
+			if res.err == nil || !p.Retryable(status.Code(res.err)) {
+				return res
+			}
+			last = res
+			switch {
+			case started >= p.MaxAttempts || stopped:
+				if pending == 0 {
+					return last
+				}
+			case p.HedgingDelay > 0:
+				start()
+			case pending == 0:
+				// random in [0, backoff], see https://github.com/grpc/proposal/blob/master/A6-client-retries.md
+				next = time.After(time.Duration(rand.Int63n(int64(backoff) + 1)))
+				backoff = time.Duration(float64(backoff) * p.BackoffMultiplier)
+				if backoff > p.MaxBackoff {
+					backoff = p.MaxBackoff
+				}
+			}
+		case <-next:
+			start()
+		case <-done:
+			if pending == 0 {
+				return last
+			}
+			// pending attempts fail soon, don't start new ones
+			done, next, stopped = nil, nil, true
+		}
+	}
+}
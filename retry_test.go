@@ -0,0 +1,210 @@
+package gapi
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type serverCodec struct {
+	rawCodec
+}
+
+func (serverCodec) String() string {
+	return "raw"
+}
+
+// testUpstream serves every method in process, handle is called with the
+// index of the attempt.
+type testUpstream struct {
+	mu       sync.Mutex
+	attempts int
+	// the first message of each attempt
+	reqs   [][]byte
+	handle func(n int, stream grpc.ServerStream) error
+}
+
+func (u *testUpstream) count() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.attempts
+}
+
+func (u *testUpstream) serve(srv interface{}, stream grpc.ServerStream) error {
+	var req []byte
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+	u.mu.Lock()
+	n := u.attempts
+	u.attempts++
+	u.reqs = append(u.reqs, req)
+	u.mu.Unlock()
+	return u.handle(n, stream)
+}
+
+func newTestHandler(t *testing.T, handle func(n int, stream grpc.ServerStream) error) (*routeHandler, *testUpstream) {
+	lis := bufconn.Listen(1 << 20)
+	u := &testUpstream{handle: handle}
+	srv := grpc.NewServer(grpc.CustomCodec(serverCodec{}), grpc.UnknownServiceHandler(u.serve))
+	go srv.Serve(lis)
+	cc, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cc.Close()
+		srv.Stop()
+	})
+	headers, err := compileHeaderRules(nil, nil, &metadata.RouteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &routeHandler{
+		s:       NewServer(),
+		call:    &metadata.Call{Server: "bufnet", Name: "/test.Test/Call"},
+		client:  &upstream{ClientConn: cc, server: "bufnet"},
+		headers: headers,
+	}
+	return h, u
+}
+
+func reply(stream grpc.ServerStream) error {
+	return stream.SendMsg([]byte("ok"))
+}
+
+func TestRetry(t *testing.T) {
+	policy := func(attempts int) *metadata.RetryPolicy {
+		return &metadata.RetryPolicy{
+			MaxAttempts:       attempts,
+			InitialBackoff:    time.Millisecond,
+			MaxBackoff:        5 * time.Millisecond,
+			BackoffMultiplier: 2,
+			RetryableCodes:    []codes.Code{codes.Unavailable},
+		}
+	}
+	tests := []struct {
+		name     string
+		attempts int
+		fail     int
+		code     codes.Code
+		want     codes.Code
+		sent     int
+	}{
+		{"retryable", 3, 2, codes.Unavailable, codes.OK, 3},
+		{"not retryable", 3, 2, codes.InvalidArgument, codes.InvalidArgument, 1},
+		{"attempts run out", 3, 5, codes.Unavailable, codes.Unavailable, 3},
+	}
+	for _, c := range tests {
+		h, u := newTestHandler(t, func(n int, stream grpc.ServerStream) error {
+			if n < c.fail {
+				return status.Error(c.code, "failed")
+			}
+			return reply(stream)
+		})
+		res := h.retry(context.Background(), policy(c.attempts), nil)
+		if code := status.Code(res.err); code != c.want {
+			t.Errorf("%s: code %v, want %v", c.name, code, c.want)
+		}
+		if c.want == codes.OK && string(res.data) != "ok" {
+			t.Errorf("%s: data %q", c.name, res.data)
+		}
+		if n := u.count(); n != c.sent {
+			t.Errorf("%s: %d attempts, want %d", c.name, n, c.sent)
+		}
+	}
+}
+
+func TestRetryCancel(t *testing.T) {
+	h, u := newTestHandler(t, func(n int, stream grpc.ServerStream) error {
+		return status.Error(codes.Unavailable, "failed")
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := &metadata.RetryPolicy{
+		MaxAttempts:       5,
+		InitialBackoff:    time.Hour,
+		MaxBackoff:        time.Hour,
+		BackoffMultiplier: 1,
+		RetryableCodes:    []codes.Code{codes.Unavailable},
+	}
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	res := h.retry(ctx, policy, nil)
+	if time.Since(start) > time.Second {
+		t.Fatal("backoff isn't interrupted")
+	}
+	if status.Code(res.err) != codes.Unavailable {
+		t.Fatalf("error %v", res.err)
+	}
+	if n := u.count(); n != 1 {
+		t.Fatalf("%d attempts", n)
+	}
+}
+
+func TestHedging(t *testing.T) {
+	cancelled := make(chan struct{})
+	h, u := newTestHandler(t, func(n int, stream grpc.ServerStream) error {
+		if n == 0 {
+			// the slow one loses
+			<-stream.Context().Done()
+			close(cancelled)
+			return stream.Context().Err()
+		}
+		return reply(stream)
+	})
+	policy := &metadata.RetryPolicy{
+		MaxAttempts:    3,
+		HedgingDelay:   10 * time.Millisecond,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	}
+	res := h.retry(context.Background(), policy, nil)
+	if res.err != nil || string(res.data) != "ok" {
+		t.Fatalf("result %q, %v", res.data, res.err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("the loser isn't cancelled")
+	}
+	if n := u.count(); n != 2 {
+		t.Fatalf("%d attempts", n)
+	}
+}
+
+func TestRetryBreakerOpen(t *testing.T) {
+	h, u := newTestHandler(t, func(n int, stream grpc.ServerStream) error {
+		return reply(stream)
+	})
+	h.breaker = newBreaker("bufnet", BreakerPolicy{})
+	h.breaker.open(time.Now())
+	for _, delay := range []time.Duration{0, 10 * time.Millisecond} {
+		policy := &metadata.RetryPolicy{
+			MaxAttempts:       3,
+			InitialBackoff:    time.Millisecond,
+			MaxBackoff:        time.Millisecond,
+			BackoffMultiplier: 1,
+			RetryableCodes:    []codes.Code{codes.Unavailable},
+			HedgingDelay:      delay,
+		}
+		res := h.retry(context.Background(), policy, nil)
+		if _, ok := res.err.(*breakerError); !ok {
+			t.Fatalf("error %v", res.err)
+		}
+		if status.Code(res.err) != codes.Unavailable {
+			t.Fatalf("code %v", status.Code(res.err))
+		}
+	}
+	if n := u.count(); n != 0 {
+		t.Fatalf("%d attempts", n)
+	}
+}
@@ -3,45 +3,184 @@ package gapi
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/sirupsen/logrus"
 	"github.com/zhiduoke/gapi/metadata"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcmd "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type routeHandler struct {
-	s      *Server
-	chain  []HandleFunc
-	call   *metadata.Call
-	ch     CallHandler
-	client *grpc.ClientConn
+	s        *Server
+	chain    []HandleFunc
+	route    *metadata.Route
+	call     *metadata.Call
+	ch       CallHandler
+	client   *upstream
+	resolver metadata.MessageResolver
+	headers  *headerRules
+	breaker  *breaker
 }
 
 func (h *routeHandler) invoke(ctx *Context) error {
-	rpcctx := ctx.req.Context()
 	call := h.call
-	var cancel func()
-	if call.Timeout != 0 {
-		rpcctx, cancel = context.WithTimeout(rpcctx, call.Timeout)
+	sh, _ := h.ch.(StreamHandler)
+	var invoke func(ctx *Context) error
+	switch {
+	case call.Upload != nil:
+		invoke = h.invokeUpload
+	case sh != nil && (call.ClientStreaming || call.ServerStreaming):
+		invoke = func(ctx *Context) error {
+			return sh.HandleStream(call, ctx, func(rpcctx context.Context) (grpc.ClientStream, error) {
+				return h.openStream(h.outgoingContext(ctx, rpcctx))
+			})
+		}
+	case call.ServerStreaming:
+		invoke = h.invokeStream
+	default:
+		// the breaker is applied to each attempt
+		return h.invokeUnary(ctx)
 	}
-	err := h.client.Invoke(rpcctx, call.Name, ctx, ctx, grpc.ForceCodec(&callCodec{
-		call: call,
-		h:    h.ch,
-	}))
-	if cancel != nil {
-		cancel()
+	if err := h.breaker.allow(); err != nil {
+		return err
 	}
+	err := invoke(ctx)
+	h.breaker.done(err, 0)
 	return err
 }
 
+func (h *routeHandler) invokeUnary(ctx *Context) error {
+	call := h.call
+	// encode once, the request may be sent several times
+	req, err := h.encodeRequest(ctx)
+	if err != nil {
+		return err
+	}
+	rpcctx, cancel := h.rpcContext(ctx)
+	defer cancel()
+	var res *attemptResult
+	if call.Retry != nil {
+		res = h.retry(rpcctx, call.Retry, req)
+	} else {
+		res = h.attempt(rpcctx, req)
+	}
+	h.setHeader(ctx, res.header)
+	h.setTrailer(ctx, res.trailer, false)
+	if res.err != nil {
+		return res.err
+	}
+	return h.ch.WriteResponse(call, ctx, res.data)
+}
+
+type attemptResult struct {
+	header  grpcmd.MD
+	trailer grpcmd.MD
+	data    []byte
+	err     error
+}
+
+// attempt sends an unary call through the breaker.
+func (h *routeHandler) attempt(rpcctx context.Context, req []byte) *attemptResult {
+	if err := h.breaker.allow(); err != nil {
+		return &attemptResult{err: err}
+	}
+	start := time.Now()
+	res := h.send(rpcctx, req)
+	h.breaker.done(res.err, time.Since(start))
+	return res
+}
+
+// send sends an unary call, the stream is used to receive the header.
+func (h *routeHandler) send(rpcctx context.Context, req []byte) *attemptResult {
+	call := h.call
+	desc := &grpc.StreamDesc{StreamName: call.Name}
+	stream, err := h.client.NewStream(rpcctx, desc, call.Name, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return &attemptResult{err: err}
+	}
+	if _, err = SendMsg(stream, req); err != nil {
+		return &attemptResult{err: err}
+	}
+	if err = stream.CloseSend(); err != nil {
+		return &attemptResult{err: err}
+	}
+	res := &attemptResult{}
+	res.header, _ = stream.Header()
+	res.err = stream.RecvMsg(&res.data)
+	res.trailer = stream.Trailer()
+	return res
+}
+
+// recvResponse receives the only message of the response, the trailer is
+// written as headers before the body.
+func (h *routeHandler) recvResponse(ctx *Context, stream grpc.ClientStream) error {
+	h.recvHeader(ctx, stream)
+	var data []byte
+	err := stream.RecvMsg(&data)
+	h.recvTrailer(ctx, stream, false)
+	if err != nil {
+		return err
+	}
+	return h.ch.WriteResponse(h.call, ctx, data)
+}
+
+// rpcContext returns the context of the upstream call, which is cancelled when
+// the http client disconnects as well.
+func (h *routeHandler) rpcContext(ctx *Context) (context.Context, context.CancelFunc) {
+	var (
+		rpcctx context.Context
+		cancel context.CancelFunc
+	)
+	if h.call.Timeout != 0 {
+		rpcctx, cancel = context.WithTimeout(ctx.req.Context(), h.call.Timeout)
+	} else {
+		rpcctx, cancel = context.WithCancel(ctx.req.Context())
+	}
+	return h.outgoingContext(ctx, rpcctx), cancel
+}
+
+func (h *routeHandler) writeError(ctx *Context, err error) {
+	if ew, ok := h.ch.(ErrorWriter); ok {
+		if ew.WriteError(h.call, ctx, err) == nil {
+			return
+		}
+	}
+	if h.s.ErrorRenderer != nil {
+		h.s.ErrorRenderer(ctx, err)
+		return
+	}
+	h.s.WriteError(ctx, err)
+}
+
 func (h *routeHandler) handle(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
 	ctx := h.s.ctxpool.Get().(*Context)
 	ctx.reset(w, req, params, h.chain)
-	err := ctx.Next()
+	ctx.route = h.route
+	ctx.call = h.call
+	ctx.resolver = h.resolver
+	err := h.serve(ctx)
 	if err != nil {
 		logrus.Errorf("handle route: %v", err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		h.writeError(ctx, err)
 	}
 	h.s.ctxpool.Put(ctx)
 }
+
+// serve runs the chain, the connection is closed after requests in flight
+// finished when it's removed or the server shuts down.
+func (h *routeHandler) serve(ctx *Context) error {
+	if !h.s.calls.acquire() {
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	defer h.s.calls.release()
+	if !h.client.calls.acquire() {
+		// routes were updated after the request had been routed
+		return status.Errorf(codes.Unavailable, "connection of %s is closing", h.call.Server)
+	}
+	defer h.client.calls.release()
+	return ctx.Next()
+}
@@ -0,0 +1,118 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+type httpRoute struct {
+	route *metadata.Route
+	path  string
+}
+
+// HTTPRouter registers paths in httprouter syntax, templates are supported
+// if variables are single segments like /v1/books/{id}.
+type HTTPRouter struct {
+	router *httprouter.Router
+	routes []httpRoute
+}
+
+func NewHTTPRouter(notFound http.Handler) *HTTPRouter {
+	router := httprouter.New()
+	router.NotFound = notFound
+	return &HTTPRouter{router: router}
+}
+
+func (r *HTTPRouter) Handle(route *metadata.Route, handle httprouter.Handle) (err error) {
+	path := route.Path
+	if b := route.Call.Binding; b != nil {
+		path, err = httprouterPath(b.Template)
+		if err != nil {
+			return err
+		}
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			err = r.conflict(route, path, fmt.Sprint(v))
+		}
+	}()
+	r.router.Handle(route.Method, path, handle)
+	r.routes = append(r.routes, httpRoute{route: route, path: path})
+	return nil
+}
+
+func (r *HTTPRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.router.ServeHTTP(w, req)
+}
+
+// conflict returns the panic of httprouter as an error.
+func (r *HTTPRouter) conflict(route *metadata.Route, path string, reason string) error {
+	e := &ConflictError{
+		Route:  route,
+		Reason: reason,
+	}
+	for _, registered := range r.routes {
+		if registered.route.Method == route.Method && pathsConflict(registered.path, path) {
+			e.With = append(e.With, registered.route)
+		}
+	}
+	return e
+}
+
+// pathsConflict reports whether paths can't be registered together in
+// httprouter, i.e. the same path, or a parameter and another segment at the
+// same position after the same prefix.
+func pathsConflict(a, b string) bool {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, y := as[i], bs[i]
+		if x == y {
+			continue
+		}
+		wx := strings.HasPrefix(x, ":") || strings.HasPrefix(x, "*")
+		wy := strings.HasPrefix(y, ":") || strings.HasPrefix(y, "*")
+		// different static segments diverge
+		return wx || wy
+	}
+	if len(as) == len(bs) {
+		return true
+	}
+	// a catch-all matches longer paths as well
+	if len(as) > len(bs) {
+		as, bs = bs, as
+	}
+	return strings.HasPrefix(as[len(as)-1], "*")
+}
+
+// httprouterPath converts a template whose variables are single segments.
+func httprouterPath(t *metadata.PathTemplate) (string, error) {
+	if t.Verb != "" {
+		return "", fmt.Errorf("verb :%s requires TemplateRouter", t.Verb)
+	}
+	var b strings.Builder
+	for i, seg := range t.Segments {
+		b.WriteByte('/')
+		if seg.Kind == metadata.LiteralSegment {
+			b.WriteString(seg.Literal)
+			continue
+		}
+		var name string
+		for _, v := range t.Variables {
+			if v.Start == i && v.End == i+1 && seg.Kind == metadata.WildcardSegment {
+				name = v.FieldPath
+			}
+		}
+		if name == "" {
+			return "", fmt.Errorf("wildcards which aren't single variables require TemplateRouter")
+		}
+		b.WriteString(":" + name)
+	}
+	if len(t.Segments) == 0 {
+		b.WriteByte('/')
+	}
+	return b.String(), nil
+}
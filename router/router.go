@@ -0,0 +1,20 @@
+// Package router implements routers of gapi: HTTPRouter is based on
+// httprouter, TemplateRouter matches path templates of google.api.http.
+package router
+
+import (
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+// ConflictError is returned if a route can't be registered with registered
+// ones.
+type ConflictError struct {
+	Route *metadata.Route
+	// With are the registered routes it conflicts with.
+	With   []*metadata.Route
+	Reason string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Reason
+}
@@ -0,0 +1,270 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+type leaf struct {
+	verb     string
+	route    *metadata.Route
+	template *metadata.PathTemplate
+	handle   httprouter.Handle
+	// catch-all parameter of httprouter syntax, whose value starts with '/'
+	catchAll string
+}
+
+type node struct {
+	literals map[string]*node
+	wildcard *node
+	deep     *node
+	leaves   []*leaf
+}
+
+func (n *node) child(seg metadata.Segment) *node {
+	var p **node
+	switch seg.Kind {
+	case metadata.WildcardSegment:
+		p = &n.wildcard
+	case metadata.DeepWildcardSegment:
+		p = &n.deep
+	default:
+		if n.literals == nil {
+			n.literals = map[string]*node{}
+		}
+		c := n.literals[seg.Literal]
+		if c == nil {
+			c = &node{}
+			n.literals[seg.Literal] = c
+		}
+		return c
+	}
+	if *p == nil {
+		*p = &node{}
+	}
+	return *p
+}
+
+func (n *node) leaf(verb string) *leaf {
+	for _, l := range n.leaves {
+		if l.verb == verb {
+			return l
+		}
+	}
+	return nil
+}
+
+// lookup prefers literals to wildcards, and wildcards to deep wildcards.
+func (n *node) lookup(segs []string, verb string) *leaf {
+	if len(segs) == 0 {
+		if l := n.leaf(verb); l != nil {
+			return l
+		}
+	} else {
+		if c := n.literals[segs[0]]; c != nil {
+			if l := c.lookup(segs[1:], verb); l != nil {
+				return l
+			}
+		}
+		if n.wildcard != nil && segs[0] != "" {
+			if l := n.wildcard.lookup(segs[1:], verb); l != nil {
+				return l
+			}
+		}
+	}
+	if n.deep != nil {
+		return n.deep.leaf(verb)
+	}
+	return nil
+}
+
+// TemplateRouter matches path templates of google.api.http, paths in
+// httprouter syntax are registered as templates, e.g. /users/:id/*path is
+// /users/{id}/{path=**}. Literal segments are preferred to variables, so
+// /users/{id} and /users/me don't conflict.
+type TemplateRouter struct {
+	notFound http.Handler
+	trees    map[string]*node
+}
+
+func NewTemplateRouter(notFound http.Handler) *TemplateRouter {
+	return &TemplateRouter{
+		notFound: notFound,
+		trees:    map[string]*node{},
+	}
+}
+
+func (r *TemplateRouter) Handle(route *metadata.Route, handle httprouter.Handle) error {
+	l := &leaf{
+		route:  route,
+		handle: handle,
+	}
+	if b := route.Call.Binding; b != nil {
+		l.template = b.Template
+	} else {
+		var err error
+		l.template, l.catchAll, err = parseRouterPath(route.Path)
+		if err != nil {
+			return err
+		}
+	}
+	l.verb = l.template.Verb
+	n := r.trees[route.Method]
+	if n == nil {
+		n = &node{}
+		r.trees[route.Method] = n
+	}
+	segs := l.template.Segments
+	if len(segs) == 0 {
+		// the path is /
+		segs = []metadata.Segment{{Kind: metadata.LiteralSegment}}
+	}
+	for _, seg := range segs {
+		n = n.child(seg)
+	}
+	if registered := n.leaf(l.verb); registered != nil {
+		return &ConflictError{
+			Route:  route,
+			With:   []*metadata.Route{registered.route},
+			Reason: fmt.Sprintf("%s %s matches the same paths as %s", route.Method, route.Path, registered.route.Path),
+		}
+	}
+	n.leaves = append(n.leaves, l)
+	return nil
+}
+
+// parseRouterPath parses a path in httprouter syntax as a template.
+func parseRouterPath(path string) (*metadata.PathTemplate, string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, "", fmt.Errorf("path %s must start with '/'", path)
+	}
+	var (
+		t        = &metadata.PathTemplate{}
+		catchAll string
+		segs     = strings.Split(path[1:], "/")
+	)
+	for i, seg := range segs {
+		switch {
+		case strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*"):
+			name := seg[1:]
+			if name == "" || strings.ContainsAny(name, ":*") {
+				return nil, "", fmt.Errorf("invalid parameter %s in path %s", seg, path)
+			}
+			kind := metadata.WildcardSegment
+			if seg[0] == '*' {
+				if i != len(segs)-1 {
+					return nil, "", fmt.Errorf("catch-all must be the last segment in path %s", path)
+				}
+				kind, catchAll = metadata.DeepWildcardSegment, name
+			}
+			t.Variables = append(t.Variables, &metadata.TemplateVariable{
+				FieldPath: name,
+				Start:     i,
+				End:       i + 1,
+			})
+			t.Segments = append(t.Segments, metadata.Segment{Kind: kind})
+		case strings.ContainsAny(seg, ":*"):
+			return nil, "", fmt.Errorf("parameters must be whole segments in path %s", path)
+		default:
+			t.Segments = append(t.Segments, metadata.Segment{Kind: metadata.LiteralSegment, Literal: seg})
+		}
+	}
+	return t, catchAll, nil
+}
+
+func (r *TemplateRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	segs, ok := splitPath(req.URL.EscapedPath())
+	if ok {
+		if n := r.trees[req.Method]; n != nil {
+			if l, params := match(n, segs); l != nil {
+				l.handle(w, req, params)
+				return
+			}
+		}
+		// is the path allowed by other methods
+		var allowed []string
+		for method, n := range r.trees {
+			if method == req.Method {
+				continue
+			}
+			if l, _ := match(n, segs); l != nil {
+				allowed = append(allowed, method)
+			}
+		}
+		if len(allowed) > 0 {
+			sort.Strings(allowed)
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+	}
+	if r.notFound != nil {
+		r.notFound.ServeHTTP(w, req)
+	} else {
+		http.NotFound(w, req)
+	}
+}
+
+// splitPath splits the escaped path into unescaped segments, escaped slashes
+// are kept as %2F.
+func splitPath(path string) ([]string, bool) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+	segs := strings.Split(path[1:], "/")
+	for i, seg := range segs {
+		if strings.IndexByte(seg, '%') < 0 {
+			continue
+		}
+		seg = strings.Replace(seg, "%2F", "%252F", -1)
+		seg = strings.Replace(seg, "%2f", "%252F", -1)
+		v, err := url.PathUnescape(seg)
+		if err != nil {
+			return nil, false
+		}
+		segs[i] = v
+	}
+	return segs, true
+}
+
+func match(n *node, segs []string) (*leaf, httprouter.Params) {
+	last := segs[len(segs)-1]
+	// try the verb first, a variable may contain ':' as well
+	if i := strings.LastIndexByte(last, ':'); i >= 0 {
+		verbSegs := append(segs[:len(segs)-1:len(segs)-1], last[:i])
+		if l := n.lookup(verbSegs, last[i+1:]); l != nil {
+			return l, l.params(verbSegs)
+		}
+	}
+	if l := n.lookup(segs, ""); l != nil {
+		return l, l.params(segs)
+	}
+	return nil, nil
+}
+
+func (l *leaf) params(segs []string) httprouter.Params {
+	vars := l.template.Variables
+	if len(vars) == 0 {
+		return nil
+	}
+	params := make(httprouter.Params, 0, len(vars))
+	for _, v := range vars {
+		end := v.End
+		if end == len(l.template.Segments) {
+			// a deep wildcard takes the rest
+			end = len(segs)
+		}
+		value := strings.Join(segs[v.Start:end], "/")
+		if v.FieldPath == l.catchAll {
+			value = "/" + value
+		}
+		params = append(params, httprouter.Param{Key: v.FieldPath, Value: value})
+	}
+	return params
+}
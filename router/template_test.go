@@ -0,0 +1,138 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+func newRoute(t *testing.T, method, path string, template bool) *metadata.Route {
+	route := &metadata.Route{Method: method, Path: path, Call: &metadata.Call{}}
+	if template {
+		tmpl, err := metadata.ParseTemplate(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		route.Call.Binding = &metadata.Binding{Template: tmpl}
+	}
+	return route
+}
+
+func TestTemplateRouter(t *testing.T) {
+	r := NewTemplateRouter(nil)
+	for _, c := range []struct {
+		method   string
+		path     string
+		template bool
+	}{
+		{"GET", "/v1/{name=shelves/*/books/*}", true},
+		{"GET", "/v1/shelves/special/books/{id}", true},
+		{"GET", "/v1/{path=files/**}", true},
+		{"POST", "/v1/{name}:cancel", true},
+		{"GET", "/v1/{name}", true},
+		{"GET", "/users/:id", false},
+		{"GET", "/users/me", false},
+		{"GET", "/static/*path", false},
+	} {
+		route := newRoute(t, c.method, c.path, c.template)
+		err := r.Handle(route, func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+			w.Header().Set("Route", route.Method+" "+route.Path)
+			for _, p := range params {
+				w.Header().Add("Param", p.Key+"="+p.Value)
+			}
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		route  string
+		params []string
+	}{
+		{"GET", "/v1/shelves/a/books/b", "GET /v1/{name=shelves/*/books/*}", []string{"name=shelves/a/books/b"}},
+		// literals are preferred
+		{"GET", "/v1/shelves/special/books/b", "GET /v1/shelves/special/books/{id}", []string{"id=b"}},
+		{"GET", "/v1/files/a/b/c", "GET /v1/{path=files/**}", []string{"path=files/a/b/c"}},
+		// ** matches no segment as well
+		{"GET", "/v1/files", "GET /v1/{path=files/**}", []string{"path=files"}},
+		{"POST", "/v1/a:cancel", "POST /v1/{name}:cancel", []string{"name=a"}},
+		// a variable without the verb
+		{"GET", "/v1/a:cancel", "GET /v1/{name}", []string{"name=a:cancel"}},
+		// escaped slashes stay in the segment
+		{"GET", "/v1/a%2Fb", "GET /v1/{name}", []string{"name=a%2Fb"}},
+		{"GET", "/v1/a%20b", "GET /v1/{name}", []string{"name=a b"}},
+		{"GET", "/users/me", "GET /users/me", nil},
+		{"GET", "/users/1", "GET /users/:id", []string{"id=1"}},
+		{"GET", "/static/js/app.js", "GET /static/*path", []string{"path=/js/app.js"}},
+	}
+	for _, c := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(c.method, c.path, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s %s: status %d", c.method, c.path, w.Code)
+			continue
+		}
+		if got := w.Header().Get("Route"); got != c.route {
+			t.Errorf("%s %s: route %s, want %s", c.method, c.path, got, c.route)
+		}
+		params := w.Header()["Param"]
+		if len(params) != len(c.params) {
+			t.Errorf("%s %s: params %v, want %v", c.method, c.path, params, c.params)
+			continue
+		}
+		for i := range params {
+			if params[i] != c.params[i] {
+				t.Errorf("%s %s: params %v, want %v", c.method, c.path, params, c.params)
+			}
+		}
+	}
+
+	for _, c := range []struct {
+		method string
+		path   string
+		status int
+		allow  string
+	}{
+		{"DELETE", "/users/me", http.StatusMethodNotAllowed, "GET"},
+		{"GET", "/v1/a:unknown/b", http.StatusNotFound, ""},
+		{"GET", "/nothing/here", http.StatusNotFound, ""},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(c.method, c.path, nil))
+		if w.Code != c.status || w.Header().Get("Allow") != c.allow {
+			t.Errorf("%s %s: status %d, allow %q", c.method, c.path, w.Code, w.Header().Get("Allow"))
+		}
+	}
+	if _, ok := splitPath("/v1/%zz"); ok {
+		t.Error("invalid escape accepted")
+	}
+}
+
+func TestTemplateRouterConflict(t *testing.T) {
+	nop := func(http.ResponseWriter, *http.Request, httprouter.Params) {}
+	r := NewTemplateRouter(nil)
+	first := newRoute(t, "GET", "/v1/{name}", true)
+	if err := r.Handle(first, nop); err != nil {
+		t.Fatal(err)
+	}
+	// the same paths in httprouter syntax
+	err := r.Handle(newRoute(t, "GET", "/v1/:id", false), nop)
+	ce, ok := err.(*ConflictError)
+	if !ok || len(ce.With) != 1 || ce.With[0] != first {
+		t.Fatalf("error %v", err)
+	}
+	for _, path := range []string{"/v1/{name}:cancel", "/v1/me"} {
+		if err := r.Handle(newRoute(t, "GET", path, true), nop); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+	if err := r.Handle(newRoute(t, "POST", "/v1/{name}", true), nop); err != nil {
+		t.Error(err)
+	}
+}
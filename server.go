@@ -5,9 +5,12 @@ import (
 	"net/http"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/zhiduoke/gapi/discovery"
 	"github.com/zhiduoke/gapi/metadata"
+	gapirouter "github.com/zhiduoke/gapi/router"
 	"google.golang.org/grpc"
 )
 
@@ -18,14 +21,47 @@ type CallHandler interface {
 
 type HandleFunc func(ctx *Context) error
 
+// Router dispatches requests to routes, see package router.
+type Router interface {
+	http.Handler
+	// Handle registers a route, params passed to handle are named by
+	// parameters of the path or FieldPath of template variables. It returns
+	// an error if the path is invalid or conflicts with registered routes.
+	Handle(route *metadata.Route, handle httprouter.Handle) error
+}
+
+// routerBox keeps the type of atomic.Value consistent.
+type routerBox struct {
+	Router
+}
+
+// MiddlewareFactory builds a middleware from the arguments of a use entry, it's
+// called once per route at UpdateRoute, errors fail the update.
+type MiddlewareFactory func(m *metadata.Middleware) (HandleFunc, error)
+
+// MiddlewareValidator checks the arguments of a use entry without building the
+// middleware, it's called instead of the factory by a dry run.
+type MiddlewareValidator func(m *metadata.Middleware) error
+
 type Server struct {
-	router      atomic.Value
-	ctxpool     sync.Pool
-	routeLock   sync.Mutex
-	clients     map[string]*grpc.ClientConn
+	router    atomic.Value
+	ctxpool   sync.Pool
+	routeLock sync.Mutex
+	clients   map[string]*upstream
+	breakers  map[string]*breaker
+	closed    bool
+	calls     tracker
+	// the installed metadata, handlers of routes and the generation of
+	// middlewares they use
+	md          *metadata.Metadata
+	handlers    map[string]*routeHandler
+	mwgen       int
 	middlewares struct {
 		sync.RWMutex
-		inner map[string]HandleFunc
+		inner      map[string]MiddlewareFactory
+		validators map[string]MiddlewareValidator
+		// incremented on registration
+		gen int
 	}
 	callHandlers struct {
 		sync.RWMutex
@@ -33,8 +69,29 @@ type Server struct {
 	}
 
 	globalUses []HandleFunc
-	Dial       func(string) (*grpc.ClientConn, error)
-	NotFound   http.Handler
+	// Dial dials upstream servers, the balancer of services is ignored if
+	// it's set. discovery.Dial by default, which resolves static, srv and
+	// file targets as well.
+	Dial     func(string) (*grpc.ClientConn, error)
+	NotFound http.Handler
+	// NewRouter creates a router for each UpdateRoute, router.NewHTTPRouter
+	// by default. router.NewTemplateRouter supports all templates of
+	// google.api.http.
+	NewRouter func(notFound http.Handler) Router
+	// ErrorRenderer renders errors of routes, Server.WriteError by default.
+	ErrorRenderer func(ctx *Context, err error)
+	// HeaderPolicy decides the request headers forwarded to upstreams, it's
+	// extended by forward_headers of each route.
+	HeaderPolicy *HeaderPolicy
+	// ForwardContext lists context values forwarded as metadata for all
+	// routes, "uid" or "uid=x-user-id" to rename, see forward_context.
+	ForwardContext []string
+	// Breaker enables a circuit breaker per upstream server, it takes effect
+	// on next UpdateRoute.
+	Breaker *BreakerPolicy
+	// DrainTimeout is the max time to wait for calls in flight before closing
+	// connections removed by UpdateRoute, 30s by default.
+	DrainTimeout time.Duration
 }
 
 func (s *Server) getCallHandler(name string) CallHandler {
@@ -51,104 +108,260 @@ func (s *Server) RegisterHandler(name string, h CallHandler) {
 }
 
 func (s *Server) Use(handle HandleFunc) {
+	s.middlewares.Lock()
 	s.globalUses = append(s.globalUses, handle)
+	s.middlewares.gen++
+	s.middlewares.Unlock()
 }
 
-func (s *Server) generateMiddlewareChain(mws []string, exec HandleFunc) ([]HandleFunc, error) {
+func (s *Server) generateMiddlewareChain(mws []*metadata.Middleware, exec HandleFunc) ([]HandleFunc, error) {
+	s.middlewares.RLock()
+	uses := s.globalUses
+	s.middlewares.RUnlock()
 	n := len(mws) + 1
-	hs := make([]HandleFunc, 0, n+len(s.globalUses))
-	if len(s.globalUses) > 0 {
-		hs = append(hs, s.globalUses...)
+	hs := make([]HandleFunc, 0, n+len(uses))
+	if len(uses) > 0 {
+		hs = append(hs, uses...)
 	}
-	s.middlewares.RLock()
-	for _, name := range mws {
-		mw := s.middlewares.inner[name]
-		if mw == nil {
-			s.middlewares.RUnlock()
-			return nil, fmt.Errorf("no such middleware: %s", name)
+	for _, m := range mws {
+		s.middlewares.RLock()
+		factory := s.middlewares.inner[m.Name]
+		s.middlewares.RUnlock()
+		if factory == nil {
+			return nil, fmt.Errorf("no such middleware: %s", m.Name)
+		}
+		mw, err := factory(m)
+		if err != nil {
+			return nil, fmt.Errorf("middleware %s: %v", m, err)
 		}
 		hs = append(hs, mw)
 	}
-	s.middlewares.RUnlock()
 	hs = append(hs, exec)
 	return hs, nil
 }
 
+// validateMiddlewares checks middlewares of a route are registered and their
+// arguments are valid if they have validators.
+func (s *Server) validateMiddlewares(mws []*metadata.Middleware) error {
+	for _, m := range mws {
+		s.middlewares.RLock()
+		factory := s.middlewares.inner[m.Name]
+		validate := s.middlewares.validators[m.Name]
+		s.middlewares.RUnlock()
+		if factory == nil {
+			return fmt.Errorf("no such middleware: %s", m.Name)
+		}
+		if validate == nil {
+			continue
+		}
+		if err := validate(m); err != nil {
+			return fmt.Errorf("middleware %s: %v", m, err)
+		}
+	}
+	return nil
+}
+
+// RegisterMiddleware registers a middleware which takes no arguments.
 func (s *Server) RegisterMiddleware(name string, h HandleFunc) {
+	validate := func(m *metadata.Middleware) error {
+		if m.HasArgs() {
+			return fmt.Errorf("unexpected arguments")
+		}
+		return nil
+	}
+	s.RegisterMiddlewareFactory(name, func(m *metadata.Middleware) (HandleFunc, error) {
+		if err := validate(m); err != nil {
+			return nil, err
+		}
+		return h, nil
+	})
+	s.RegisterMiddlewareValidator(name, validate)
+}
+
+// RegisterMiddlewareFactory registers a middleware which takes arguments in
+// use, e.g. "ratelimit(100/s, key=context:uid)".
+func (s *Server) RegisterMiddlewareFactory(name string, f MiddlewareFactory) {
+	s.middlewares.Lock()
+	s.middlewares.inner[name] = f
+	// the validator may not match the new factory
+	delete(s.middlewares.validators, name)
+	s.middlewares.gen++
+	s.middlewares.Unlock()
+}
+
+// RegisterMiddlewareValidator registers the validator of a registered
+// factory, a dry run only checks the middleware exists without it.
+func (s *Server) RegisterMiddlewareValidator(name string, v MiddlewareValidator) {
 	s.middlewares.Lock()
-	s.middlewares.inner[name] = h
+	s.middlewares.validators[name] = v
 	s.middlewares.Unlock()
 }
 
+// UpdateOptions are options of UpdateRouteWithReport.
+type UpdateOptions struct {
+	// DryRun validates the metadata and reports the changes without
+	// applying them, no connection is dialed and no middleware or breaker
+	// is built.
+	DryRun bool
+}
+
 func (s *Server) UpdateRoute(md *metadata.Metadata) error {
+	_, err := s.UpdateRouteWithReport(md, UpdateOptions{})
+	return err
+}
+
+// UpdateRouteWithReport installs routes of md and reports the changes against
+// the installed ones. Middlewares of unchanged routes are reused unless
+// middlewares were registered after the last update.
+func (s *Server) UpdateRouteWithReport(md *metadata.Metadata, opts UpdateOptions) (*RouteReport, error) {
 	s.routeLock.Lock()
 	defer s.routeLock.Unlock()
+	if s.closed {
+		return nil, fmt.Errorf("server is shut down")
+	}
+	if err := s.checkRoutes(md.Routes); err != nil {
+		return nil, err
+	}
+	report := diffRoutes(s.md, md)
+	report.DryRun = opts.DryRun
+	s.middlewares.RLock()
+	mwgen := s.middlewares.gen
+	s.middlewares.RUnlock()
+	reuse := mwgen == s.mwgen
 
 	old := s.clients
-	clients := map[string]*grpc.ClientConn{}
+	clients := map[string]*upstream{}
 	defer func() {
 		// close new connections when error occurred
 		for server, cc := range clients {
-			if old[server] == nil {
+			if old[server] == nil && cc.ClientConn != nil {
 				cc.Close()
 			}
 		}
 	}()
 
-	dial := s.Dial
-	if dial == nil {
-		dial = defaultDial
+	breakers := map[string]*breaker{}
+	dial := func(call *metadata.Call) (*upstream, error) {
+		u := &upstream{server: call.Server}
+		var err error
+		switch {
+		case opts.DryRun:
+			if s.Dial == nil {
+				err = discovery.CheckBalancer(call.Balancer)
+			}
+		case s.Dial != nil:
+			u.ClientConn, err = s.Dial(call.Server)
+		default:
+			u.ClientConn, err = discovery.Dial(call.Server, call.Balancer)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return u, nil
 	}
 	// register routes from metadata
-	router := httprouter.New()
+	router := s.newRouter()
+	handlers := make(map[string]*routeHandler, len(md.Routes))
 	for _, route := range md.Routes {
 		ch := s.getCallHandler(route.Call.Handler)
 		if ch == nil {
-			return fmt.Errorf("no such handler: %s", route.Call.Handler)
+			return nil, fmt.Errorf("no such handler: %s", route.Call.Handler)
 		}
-		// reuse existed connection
-		client := clients[route.Call.Server]
+		// reuse existed connection, services of a server may use different
+		// balancers
+		key := clientKey(route.Call)
+		client := clients[key]
 		if client == nil {
-			client = old[route.Call.Server]
+			client = old[key]
 			if client == nil {
 				var err error
-				client, err = dial(route.Call.Server)
+				client, err = dial(route.Call)
 				if err != nil {
-					return err
+					return nil, fmt.Errorf("dial %s: %v", route.Call.Server, err)
 				}
 			}
 		}
-		clients[route.Call.Server] = client
+		clients[key] = client
+		// keep the state of existed breakers
+		b := breakers[route.Call.Server]
+		if b == nil && s.Breaker != nil && !opts.DryRun {
+			b = s.breakers[route.Call.Server]
+			if b == nil || b.policy != s.Breaker.withDefaults() {
+				b = newBreaker(route.Call.Server, *s.Breaker)
+			}
+			breakers[route.Call.Server] = b
+		}
 		rh := &routeHandler{
-			s:      s,
-			call:   route.Call,
-			ch:     ch,
-			client: client,
+			s:        s,
+			route:    route,
+			call:     route.Call,
+			ch:       ch,
+			client:   client,
+			resolver: md.Resolver,
+			breaker:  b,
 		}
-		chain, err := s.generateMiddlewareChain(route.Options.Middlewares, rh.invoke)
+		headers, err := compileHeaderRules(s.HeaderPolicy, s.ForwardContext, &route.Options)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("route %s %s: %v", route.Method, route.Path, err)
+		}
+		rh.headers = headers
+		rkey := routeKey(route)
+		prev := s.handlers[rkey]
+		switch {
+		case opts.DryRun:
+			// the routes are discarded, don't build middlewares
+			err = s.validateMiddlewares(route.Options.Middlewares)
+		case prev != nil && reuse && report.unchanged[rkey]:
+			// replace the invoke of the previous handler
+			n := len(prev.chain) - 1
+			rh.chain = append(prev.chain[:n:n], rh.invoke)
+		default:
+			rh.chain, err = s.generateMiddlewareChain(route.Options.Middlewares, rh.invoke)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("route %s %s: %v", route.Method, route.Path, err)
+		}
+		handlers[rkey] = rh
+		if err := router.Handle(route, rh.handle); err != nil {
+			return nil, fmt.Errorf("route %s %s: %v", route.Method, route.Path, err)
 		}
-		rh.chain = chain
-		router.Handle(route.Method, route.Path, rh.handle)
 	}
-	router.NotFound = s.NotFound
-	s.router.Store(router)
+	if opts.DryRun {
+		clients = nil
+		return report, nil
+	}
+	s.router.Store(routerBox{router})
+	s.md = md
+	s.handlers = handlers
+	s.mwgen = mwgen
 	s.clients = clients
+	s.breakers = breakers
 
-	for server, cc := range old {
+	drainTimeout := s.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = defaultDrainTimeout
+	}
+	for server, u := range old {
 		if clients[server] == nil {
-			cc.Close()
+			// requests may still use it with the old router
+			u.retire(drainTimeout)
 		}
 	}
 	// don't clean conns
 	clients = nil
 
-	return nil
+	return report, nil
+}
+
+func (s *Server) newRouter() Router {
+	if s.NewRouter != nil {
+		return s.NewRouter(s.NotFound)
+	}
+	return gapirouter.NewHTTPRouter(s.NotFound)
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	s.router.Load().(*httprouter.Router).ServeHTTP(w, req)
+	s.router.Load().(routerBox).ServeHTTP(w, req)
 }
 
 func NewServer() *Server {
@@ -160,8 +373,9 @@ func NewServer() *Server {
 		},
 		NotFound: http.NotFoundHandler(),
 	}
-	s.router.Store(httprouter.New())
-	s.middlewares.inner = map[string]HandleFunc{}
+	s.router.Store(routerBox{s.newRouter()})
+	s.middlewares.inner = map[string]MiddlewareFactory{}
+	s.middlewares.validators = map[string]MiddlewareValidator{}
 	s.callHandlers.inner = map[string]CallHandler{}
 	return s
 }
@@ -0,0 +1,59 @@
+package gapi
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/zhiduoke/gapi/metadata"
+)
+
+func TestDryRunMiddlewares(t *testing.T) {
+	s := NewServer()
+	s.RegisterHandler("nop", nopHandler{})
+	built := 0
+	s.RegisterMiddlewareFactory("mw", func(m *metadata.Middleware) (HandleFunc, error) {
+		built++
+		return func(ctx *Context) error {
+			return ctx.Next()
+		}, nil
+	})
+	s.RegisterMiddlewareValidator("mw", func(m *metadata.Middleware) error {
+		if len(m.Args) > 0 {
+			return fmt.Errorf("unexpected arguments")
+		}
+		return nil
+	})
+	update := func(use string) error {
+		m, err := metadata.ParseMiddleware(use)
+		if err != nil {
+			t.Fatal(err)
+		}
+		md := &metadata.Metadata{Routes: []*metadata.Route{{
+			Method:  http.MethodGet,
+			Path:    "/a",
+			Options: metadata.RouteOptions{Middlewares: []*metadata.Middleware{m}},
+			Call: &metadata.Call{
+				Server:  "127.0.0.1:1",
+				Handler: "nop",
+				Name:    "/a.A/A",
+				In:      &metadata.Message{Name: "a.Req"},
+				Out:     &metadata.Message{Name: "a.Res"},
+			},
+		}}}
+		_, err = s.UpdateRouteWithReport(md, UpdateOptions{DryRun: true})
+		return err
+	}
+	if err := update("mw"); err != nil {
+		t.Fatal(err)
+	}
+	if err := update("mw(1)"); err == nil {
+		t.Fatal("invalid arguments passed")
+	}
+	if err := update("unknown"); err == nil {
+		t.Fatal("unknown middleware passed")
+	}
+	if built != 0 {
+		t.Fatalf("factory called %d times", built)
+	}
+}
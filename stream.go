@@ -0,0 +1,207 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// StreamHandler is implemented by a CallHandler which drives streaming calls
+// by itself, e.g. over a WebSocket. Messages are sent and received as encoded
+// protobuf: stream.SendMsg([]byte) and stream.RecvMsg(*[]byte).
+type StreamHandler interface {
+	HandleStream(call *metadata.Call, ctx *Context, open StreamOpener) error
+}
+
+// StreamOpener opens the upstream stream of a call. The handler owns the
+// stream, it should cancel rpcctx once it's done. Call.Timeout is not applied.
+type StreamOpener func(rpcctx context.Context) (grpc.ClientStream, error)
+
+// SendMsg sends msg on a client stream and reports whether the stream is still
+// open. io.EOF means the stream was aborted, it's not an error as the status
+// is returned by RecvMsg.
+func SendMsg(stream grpc.ClientStream, msg interface{}) (bool, error) {
+	err := stream.SendMsg(msg)
+	if err == io.EOF {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+type streamFormat int
+
+const (
+	// newline-delimited JSON, see http://ndjson.org
+	ndjsonFormat streamFormat = iota
+	// server-sent events, see https://html.spec.whatwg.org/multipage/server-sent-events.html
+	sseFormat
+)
+
+func negotiateStreamFormat(req *http.Request) streamFormat {
+	if strings.Contains(req.Header.Get("Accept"), "text/event-stream") {
+		return sseFormat
+	}
+	return ndjsonFormat
+}
+
+// streamWriter collects everything written by CallHandler.WriteResponse and
+// sends it as a single frame per message.
+type streamWriter struct {
+	w           http.ResponseWriter
+	format      streamFormat
+	buf         []byte
+	wroteHeader bool
+}
+
+func (w *streamWriter) Header() http.Header {
+	return w.w.Header()
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// WriteHeader is ignored, the status is always 200 once the stream started.
+func (w *streamWriter) WriteHeader(int) {}
+
+func (w *streamWriter) writeHeader() {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	h := w.w.Header()
+	switch w.format {
+	case sseFormat:
+		h.Set("Content-Type", "text/event-stream")
+	default:
+		h.Set("Content-Type", "application/x-ndjson")
+	}
+	h.Set("Cache-Control", "no-cache")
+	h.Del("Content-Length")
+	w.w.WriteHeader(http.StatusOK)
+}
+
+func (w *streamWriter) writeFrame(event string) error {
+	w.writeHeader()
+	var frame []byte
+	switch w.format {
+	case sseFormat:
+		if event != "" {
+			frame = append(frame, "event: "...)
+			frame = append(frame, event...)
+			frame = append(frame, '\n')
+		}
+		for _, line := range bytes.Split(w.buf, []byte{'\n'}) {
+			frame = append(frame, "data: "...)
+			frame = append(frame, line...)
+			frame = append(frame, '\n')
+		}
+		frame = append(frame, '\n')
+	default:
+		frame = append(w.buf, '\n')
+	}
+	w.buf = w.buf[:0]
+	_, err := w.w.Write(frame)
+	if err != nil {
+		return err
+	}
+	if f, ok := w.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (w *streamWriter) writeError(err error, resolver metadata.MessageResolver) error {
+	w.buf = w.buf[:0]
+	if w.format == ndjsonFormat {
+		w.buf = append(w.buf, `{"error":`...)
+	}
+	w.buf = appendErrorJSON(w.buf, status.Convert(err), resolver)
+	if w.format == ndjsonFormat {
+		w.buf = append(w.buf, '}')
+	}
+	return w.writeFrame("error")
+}
+
+func (h *routeHandler) openStream(rpcctx context.Context) (grpc.ClientStream, error) {
+	call := h.call
+	desc := &grpc.StreamDesc{
+		StreamName:    call.Name,
+		ServerStreams: call.ServerStreaming,
+		ClientStreams: call.ClientStreaming,
+	}
+	return h.client.NewStream(rpcctx, desc, call.Name, grpc.ForceCodec(rawCodec{}))
+}
+
+func (h *routeHandler) invokeStream(ctx *Context) error {
+	call := h.call
+	req, err := h.encodeRequest(ctx)
+	if err != nil {
+		return err
+	}
+	rpcctx, cancel := h.rpcContext(ctx)
+	defer cancel()
+	desc := &grpc.StreamDesc{
+		StreamName:    call.Name,
+		ServerStreams: call.ServerStreaming,
+		ClientStreams: call.ClientStreaming,
+	}
+	codec := &callCodec{
+		call: call,
+		h:    h.ch,
+	}
+	stream, err := h.client.NewStream(rpcctx, desc, call.Name, grpc.ForceCodec(codec))
+	if err != nil {
+		return err
+	}
+	if _, err = SendMsg(stream, req); err != nil {
+		return codec.wrap(err)
+	}
+	if err = stream.CloseSend(); err != nil {
+		return err
+	}
+	h.recvHeader(ctx, stream)
+
+	sw := &streamWriter{
+		w:      ctx.resp,
+		format: negotiateStreamFormat(ctx.req),
+	}
+	ctx.resp = sw
+	defer func() {
+		ctx.resp = sw.w
+	}()
+	for {
+		err = stream.RecvMsg(ctx)
+		if err != nil {
+			break
+		}
+		if err = sw.writeFrame(""); err != nil {
+			// client has gone
+			return nil
+		}
+	}
+	if err == io.EOF {
+		sw.writeHeader()
+		h.recvTrailer(ctx, stream, true)
+		return nil
+	}
+	err = codec.wrap(err)
+	h.recvTrailer(ctx, stream, sw.wroteHeader)
+	if !sw.wroteHeader {
+		return err
+	}
+	// too late to change the status code
+	if ctx.req.Context().Err() == nil {
+		logrus.Errorf("stream %s: %v", call.Name, err)
+		sw.writeError(err, ctx.resolver)
+	}
+	return nil
+}
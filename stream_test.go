@@ -0,0 +1,121 @@
+package gapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flushRecorder records the body written by each flush.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushed []string
+}
+
+func (r *flushRecorder) Flush() {
+	r.flushed = append(r.flushed, r.Body.String())
+	r.ResponseRecorder.Flush()
+}
+
+func TestInvokeStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		msgs        []string
+		err         error
+		contentType string
+		// frames are the body after each flush
+		frames []string
+	}{
+		{
+			name:        "ndjson",
+			msgs:        []string{`{"a":1}`, `{"a":2}`},
+			contentType: "application/x-ndjson",
+			frames:      []string{"{\"a\":1}\n", "{\"a\":1}\n{\"a\":2}\n"},
+		},
+		{
+			name:        "ndjson error",
+			msgs:        []string{`{"a":1}`},
+			err:         status.Error(codes.Internal, "broken"),
+			contentType: "application/x-ndjson",
+			frames: []string{
+				"{\"a\":1}\n",
+				"{\"a\":1}\n{\"error\":{\"code\":13,\"message\":\"broken\"}}\n",
+			},
+		},
+		{
+			name:        "sse",
+			accept:      "text/event-stream",
+			msgs:        []string{`{"a":1}`, "{\n\"a\":2}"},
+			contentType: "text/event-stream",
+			frames: []string{
+				"data: {\"a\":1}\n\n",
+				"data: {\"a\":1}\n\ndata: {\ndata: \"a\":2}\n\n",
+			},
+		},
+		{
+			name:        "sse error",
+			accept:      "text/event-stream",
+			msgs:        []string{`{"a":1}`},
+			err:         status.Error(codes.NotFound, "gone"),
+			contentType: "text/event-stream",
+			frames: []string{
+				"data: {\"a\":1}\n\n",
+				"data: {\"a\":1}\n\nevent: error\ndata: {\"code\":5,\"message\":\"gone\"}\n\n",
+			},
+		},
+	}
+	for _, c := range tests {
+		h, _ := newTestHandler(t, func(n int, stream grpc.ServerStream) error {
+			for _, msg := range c.msgs {
+				if err := stream.SendMsg([]byte(msg)); err != nil {
+					return err
+				}
+			}
+			return c.err
+		})
+		h.ch = echoHandler{}
+		h.call.ServerStreaming = true
+		req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+		if c.accept != "" {
+			req.Header.Set("Accept", c.accept)
+		}
+		rec := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+		if err := h.invokeStream(&Context{req: req, resp: rec}); err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: status %d", c.name, rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != c.contentType {
+			t.Errorf("%s: content type %s", c.name, ct)
+		}
+		if strings.Join(rec.flushed, "|") != strings.Join(c.frames, "|") {
+			t.Errorf("%s: frames %q, want %q", c.name, rec.flushed, c.frames)
+		}
+	}
+}
+
+func TestInvokeStreamErrorBeforeMessages(t *testing.T) {
+	h, _ := newTestHandler(t, func(n int, stream grpc.ServerStream) error {
+		return status.Error(codes.PermissionDenied, "denied")
+	})
+	h.ch = echoHandler{}
+	h.call.ServerStreaming = true
+	rec := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	// the status is still written by the caller
+	err := h.invokeStream(&Context{req: req, resp: rec})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("error %v", err)
+	}
+	if len(rec.flushed) != 0 || rec.Body.Len() != 0 {
+		t.Fatalf("frames %q", rec.flushed)
+	}
+}
@@ -46,9 +46,14 @@ func SyncProto(name string, data []byte) error {
 
 func (u *Updater) merge() *metadata.Metadata {
 	merged := new(metadata.Metadata)
+	var resolvers metadata.Resolvers
 	for _, md := range u.srvMD {
 		merged.Routes = append(merged.Routes, md.Routes...)
+		if md.Resolver != nil {
+			resolvers = append(resolvers, md.Resolver)
+		}
 	}
+	merged.Resolver = resolvers
 	return merged
 }
 
@@ -80,5 +85,5 @@ func (u *Updater) parseProtoFile(zipData []byte) (*metadata.Metadata, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &metadata.Metadata{Routes: routes}, nil
+	return &metadata.Metadata{Routes: routes, Resolver: parser}, nil
 }
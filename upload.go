@@ -0,0 +1,154 @@
+package gapi
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"github.com/zhiduoke/gapi/proto/kvpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// maxFormValue limits the size of a non-file field in multipart uploads.
+const maxFormValue = 1 << 20
+
+// uploadBody returns the reader of the uploaded content. For multipart
+// requests it's the file part, the fields before it are returned as form.
+func uploadBody(req *http.Request, formFile string) (io.Reader, url.Values, error) {
+	form := url.Values{}
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return req.Body, form, nil
+	}
+	mr, err := req.MultipartReader()
+	if err != nil {
+		return nil, nil, err
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, nil, errors.New("missing upload file")
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		name := part.FormName()
+		if part.FileName() != "" {
+			if formFile == "" || name == formFile {
+				return part, form, nil
+			}
+			continue
+		}
+		value, err := ioutil.ReadAll(io.LimitReader(part, maxFormValue+1))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(value) > maxFormValue {
+			return nil, nil, errors.New("form value too large: " + name)
+		}
+		form.Add(name, string(value))
+	}
+}
+
+// readError converts an error of reading the body of an upload, it's only
+// Canceled if the request is gone.
+func readError(ctx *Context, err error) error {
+	var maxErr *http.MaxBytesError
+	switch {
+	case ctx.req.Context().Err() != nil:
+		return status.Error(codes.Canceled, err.Error())
+	case errors.As(err, new(*HTTPError)):
+		return err
+	case errors.As(err, &maxErr):
+		return &HTTPError{
+			Status: http.StatusRequestEntityTooLarge,
+			Err:    status.Errorf(codes.ResourceExhausted, "request body exceeds %d bytes", maxErr.Limit),
+		}
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func (h *routeHandler) invokeUpload(ctx *Context) error {
+	call := h.call
+	upload := call.Upload
+	if max := call.Limits.MaxBodyBytes; max > 0 {
+		if err := LimitBody(ctx, max); err != nil {
+			return err
+		}
+	}
+	body, form, err := uploadBody(ctx.req, upload.FormFile)
+	if err != nil {
+		return readError(ctx, err)
+	}
+	// the body is never parsed as a form, form values are the fields of a
+	// multipart body which precede the file
+	query := ctx.req.URL.Query()
+	head, err := kvpb.Encode(call.In, NewKV(ctx, func(key string) (string, bool) {
+		if vs, ok := form[key]; ok && len(vs) > 0 {
+			return vs[0], true
+		}
+		if vs, ok := query[key]; ok && len(vs) > 0 {
+			return vs[0], true
+		}
+		return "", false
+	}))
+	if err != nil {
+		return err
+	}
+	if head, err = h.bindVariables(ctx, head); err != nil {
+		return err
+	}
+	rpcctx, cancel := h.rpcContext(ctx)
+	defer cancel()
+	desc := &grpc.StreamDesc{
+		StreamName:    call.Name,
+		ClientStreams: true,
+	}
+	codec := &callCodec{
+		call: call,
+		h:    h.ch,
+	}
+	stream, err := h.client.NewStream(rpcctx, desc, call.Name, grpc.ForceCodec(codec))
+	if err != nil {
+		return err
+	}
+	tag := protowire.Number(upload.ChunkField.Tag)
+	chunk := make([]byte, upload.ChunkSize)
+	msg := head
+	for sent := false; ; sent = true {
+		n, rerr := io.ReadFull(body, chunk)
+		if rerr != nil && rerr != io.EOF && rerr != io.ErrUnexpectedEOF {
+			// cancel the call, the upload is incomplete
+			return readError(ctx, rerr)
+		}
+		// the first message is always sent, even if the body is empty
+		if n > 0 || !sent {
+			if n > 0 {
+				msg = protowire.AppendTag(msg, tag, protowire.BytesType)
+				msg = protowire.AppendBytes(msg, chunk[:n])
+			}
+			open, err := SendMsg(stream, msg)
+			if err != nil {
+				return err
+			}
+			if !open {
+				break
+			}
+		}
+		if rerr != nil {
+			break
+		}
+		// the transport may still hold the message
+		msg = nil
+	}
+	if err = stream.CloseSend(); err != nil {
+		return err
+	}
+	return h.recvResponse(ctx, stream)
+}
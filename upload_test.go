@@ -0,0 +1,240 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zhiduoke/gapi/metadata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type echoHandler struct {
+	nopHandler
+}
+
+func (echoHandler) WriteResponse(call *metadata.Call, ctx *Context, data []byte) error {
+	_, err := ctx.Response().Write(data)
+	return err
+}
+
+// newUploadHandler returns a handler of uploads in chunks of 4 bytes, and a
+// channel of the messages received by the upstream.
+func newUploadHandler(t *testing.T) (*routeHandler, *testUpstream, chan [][]byte) {
+	received := make(chan [][]byte, 1)
+	h, u := newTestHandler(t, func(n int, stream grpc.ServerStream) error {
+		var msgs [][]byte
+		for {
+			var msg []byte
+			err := stream.RecvMsg(&msg)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, msg)
+		}
+		received <- msgs
+		return reply(stream)
+	})
+	data := &metadata.Field{Tag: 2, Name: "data", Kind: metadata.BytesKind}
+	h.ch = echoHandler{}
+	h.call.ClientStreaming = true
+	h.call.In = &metadata.Message{
+		Name: "test.Upload",
+		Fields: []*metadata.Field{
+			{Tag: 1, Name: "name", Kind: metadata.StringKind},
+			data,
+		},
+	}
+	h.call.Upload = &metadata.Upload{ChunkField: data, ChunkSize: 4, FormFile: "file"}
+	return h, u, received
+}
+
+func chunkMsg(name string, chunk string) []byte {
+	var b []byte
+	if name != "" {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, name)
+	}
+	if chunk != "" {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendString(b, chunk)
+	}
+	return b
+}
+
+func multipartBody(t *testing.T, fields [][2]string, file string) (*bytes.Buffer, string) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, f := range fields {
+		if err := mw.WriteField(f[0], f[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fw, err := mw.CreateFormFile("file", "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(fw, file)
+	mw.Close()
+	return &buf, mw.FormDataContentType()
+}
+
+func TestUpload(t *testing.T) {
+	tests := []struct {
+		name string
+		req  func() *http.Request
+		want [][]byte
+	}{
+		{
+			name: "raw body",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/upload?name=alice", strings.NewReader("abcdefghij"))
+			},
+			want: [][]byte{chunkMsg("alice", "abcd"), chunkMsg("", "efgh"), chunkMsg("", "ij")},
+		},
+		{
+			name: "empty body",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/upload?name=alice", strings.NewReader(""))
+			},
+			want: [][]byte{chunkMsg("alice", "")},
+		},
+		{
+			name: "form fields precede the file",
+			req: func() *http.Request {
+				body, contentType := multipartBody(t, [][2]string{{"name", "bob"}, {"other", "x"}}, "abcdef")
+				req := httptest.NewRequest(http.MethodPost, "/upload?name=alice", body)
+				req.Header.Set("Content-Type", contentType)
+				return req
+			},
+			want: [][]byte{chunkMsg("bob", "abcd"), chunkMsg("", "ef")},
+		},
+		{
+			name: "query is the fallback of form",
+			req: func() *http.Request {
+				body, contentType := multipartBody(t, nil, "abcd")
+				req := httptest.NewRequest(http.MethodPost, "/upload?name=alice", body)
+				req.Header.Set("Content-Type", contentType)
+				return req
+			},
+			want: [][]byte{chunkMsg("alice", "abcd")},
+		},
+	}
+	for _, c := range tests {
+		h, u, received := newUploadHandler(t)
+		rec := httptest.NewRecorder()
+		if err := h.invokeUpload(&Context{req: c.req(), resp: rec}); err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if rec.Body.String() != "ok" {
+			t.Errorf("%s: response %q", c.name, rec.Body.String())
+		}
+		// the first message is kept by testUpstream
+		msgs := append(u.reqs, <-received...)
+		if len(msgs) != len(c.want) {
+			t.Errorf("%s: %d messages, want %d", c.name, len(msgs), len(c.want))
+			continue
+		}
+		for i, msg := range msgs {
+			if !bytes.Equal(msg, c.want[i]) {
+				t.Errorf("%s: message %d is %x, want %x", c.name, i, msg, c.want[i])
+			}
+		}
+	}
+}
+
+// cancelReader cancels the request once it's read.
+type cancelReader struct {
+	cancel context.CancelFunc
+}
+
+func (r *cancelReader) Read(p []byte) (int, error) {
+	r.cancel()
+	return 0, errors.New("connection reset")
+}
+
+func TestUploadReadError(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    func() *http.Request
+		status int
+		code   codes.Code
+	}{
+		{
+			name: "too large",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/upload", nil)
+				req.Body = http.MaxBytesReader(httptest.NewRecorder(), ioutil.NopCloser(strings.NewReader("abcdefghij")), 6)
+				return req
+			},
+			status: http.StatusRequestEntityTooLarge,
+			code:   codes.ResourceExhausted,
+		},
+		{
+			name: "over the limit of the call",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(strings.Repeat("a", 2000)))
+			},
+			status: http.StatusRequestEntityTooLarge,
+			code:   codes.ResourceExhausted,
+		},
+		{
+			name: "bad multipart",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("--x\r\nbroken"))
+				req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+				return req
+			},
+			status: http.StatusBadRequest,
+			code:   codes.InvalidArgument,
+		},
+		{
+			name: "missing file",
+			req: func() *http.Request {
+				var buf bytes.Buffer
+				mw := multipart.NewWriter(&buf)
+				mw.WriteField("name", "bob")
+				mw.Close()
+				req := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+				req.Header.Set("Content-Type", mw.FormDataContentType())
+				return req
+			},
+			status: http.StatusBadRequest,
+			code:   codes.InvalidArgument,
+		},
+		{
+			name: "cancelled",
+			req: func() *http.Request {
+				ctx, cancel := context.WithCancel(context.Background())
+				return httptest.NewRequest(http.MethodPost, "/upload", &cancelReader{cancel: cancel}).WithContext(ctx)
+			},
+			status: 499,
+			code:   codes.Canceled,
+		},
+	}
+	for _, c := range tests {
+		h, _, _ := newUploadHandler(t)
+		h.call.Limits.MaxBodyBytes = 1024
+		err := h.invokeUpload(&Context{req: c.req(), resp: httptest.NewRecorder()})
+		if code := status.Code(err); code != c.code {
+			t.Errorf("%s: code %v, want %v (%v)", c.name, code, c.code, err)
+		}
+		if s := HTTPStatusFromError(err); s != c.status {
+			t.Errorf("%s: status %d, want %d", c.name, s, c.status)
+		}
+	}
+}